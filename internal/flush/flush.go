@@ -0,0 +1,86 @@
+package flush
+
+import (
+	"context"
+	"sync"
+)
+
+// Barrier is sent to workers to flush. Each worker receiving it should send everything queued and buffered, then call Done
+type Barrier struct {
+	arrived sync.WaitGroup
+	release chan struct{}
+}
+
+// Done marks worker has flushed, and blocks until all workers have flushed or Flush returns,
+// so that each worker receives exactly one Barrier of a Flush
+func (b *Barrier) Done() {
+	b.arrived.Done()
+	<-b.release
+}
+
+// Requests are Barriers sent to workers of a component by Flush
+type Requests struct {
+	C    chan *Barrier
+	lock chan struct{}
+}
+
+func NewRequests() *Requests {
+	return &Requests{
+		C:    make(chan *Barrier),
+		lock: make(chan struct{}, 1),
+	}
+}
+
+// Flush sends a Barrier to each of n workers by r.C and waits for them to flush until ctx is done.
+// Flushes are serialized, otherwise workers blocked by a Barrier would never receive the other one.
+// It blocks forever without deadline of ctx if workers are stopped.
+func (r *Requests) Flush(ctx context.Context, n int) error {
+	select {
+	case r.lock <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() {
+		<-r.lock
+	}()
+
+	b := &Barrier{release: make(chan struct{})}
+	b.arrived.Add(n)
+	defer close(b.release)
+	for i := 0; i < n; i++ {
+		select {
+		case r.C <- b:
+		case <-ctx.Done():
+			for ; i < n; i++ {
+				b.arrived.Done()
+			}
+			return ctx.Err()
+		}
+	}
+	all := make(chan struct{})
+	go func() {
+		b.arrived.Wait()
+		close(all)
+	}()
+	select {
+	case <-all:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Wait calls wait in a new goroutine and waits for it to return until ctx is done
+func Wait(ctx context.Context, wait func()) error {
+	done := make(chan struct{})
+	go func() {
+		wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,46 @@
+package flush
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlush(t *testing.T) {
+	reqs := NewRequests()
+	var flushed int64
+	for i := 0; i < 3; i++ {
+		go func() {
+			for b := range reqs.C {
+				atomic.AddInt64(&flushed, 1)
+				b.Done()
+			}
+		}()
+	}
+	assert.Nil(t, reqs.Flush(context.Background(), 3))
+	assert.Equal(t, int64(3), atomic.LoadInt64(&flushed)) // each worker flushed exactly once
+
+	// concurrent flushes are serialized
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			assert.Nil(t, reqs.Flush(ctx, 3))
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(9), atomic.LoadInt64(&flushed))
+	close(reqs.C)
+
+	// workers are stopped
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, NewRequests().Flush(ctx, 3))
+}
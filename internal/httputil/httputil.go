@@ -0,0 +1,33 @@
+package httputil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Gzip compresses data, used as body with header "Content-Encoding: gzip"
+func Gzip(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RetryAfter returns interval of "Retry-After" header in seconds, capped by max. 0 is returned if header is absent or invalid
+func RetryAfter(resp *http.Response, max time.Duration) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		if d := time.Duration(seconds) * time.Second; d < max {
+			return d
+		}
+		return max
+	}
+	return 0
+}
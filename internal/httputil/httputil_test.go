@@ -0,0 +1,32 @@
+package httputil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGzip(t *testing.T) {
+	data, err := Gzip([]byte("hello"))
+	assert.Nil(t, err)
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	assert.Nil(t, err)
+	raw, _ := ioutil.ReadAll(r)
+	assert.Equal(t, "hello", string(raw))
+}
+
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	assert.Equal(t, time.Duration(0), RetryAfter(resp, time.Minute))
+	resp.Header.Set("Retry-After", "3")
+	assert.Equal(t, 3*time.Second, RetryAfter(resp, time.Minute))
+	resp.Header.Set("Retry-After", "120")
+	assert.Equal(t, time.Minute, RetryAfter(resp, time.Minute))
+	resp.Header.Set("Retry-After", "Wed, 21 Oct 2015 07:28:00 GMT")
+	assert.Equal(t, time.Duration(0), RetryAfter(resp, time.Minute))
+}
@@ -0,0 +1,193 @@
+// Package otlphttp exports OTLP protobuf messages over HTTP. It is shared by trace sender, log collector and metrics client.
+package otlphttp
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/httputil"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	TracesPath  = "/v1/traces"
+	LogsPath    = "/v1/logs"
+	MetricsPath = "/v1/metrics"
+
+	defaultTimeout         = 10 * time.Second
+	defaultRetryCount      = 3
+	defaultBackoffInterval = time.Second
+	maxBackoffInterval     = 30 * time.Second
+)
+
+type Config struct {
+	Endpoint string // base url of collector, like http://localhost:4318
+	Headers  map[string]string
+
+	DisableGzip bool
+	Timeout     time.Duration
+
+	RetryCount      int
+	BackoffInterval time.Duration // initial backoff, doubled after each retry
+}
+
+type Client struct {
+	client          *http.Client
+	endpoint        string
+	headers         map[string]string
+	gzip            bool
+	retryCount      int
+	backoffInterval time.Duration
+
+	sleep func(time.Duration) // for test
+}
+
+func NewClient(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.RetryCount < 0 {
+		cfg.RetryCount = 0
+	} else if cfg.RetryCount == 0 {
+		cfg.RetryCount = defaultRetryCount
+	}
+	if cfg.BackoffInterval <= 0 {
+		cfg.BackoffInterval = defaultBackoffInterval
+	}
+	return &Client{
+		client:          &http.Client{Timeout: cfg.Timeout},
+		endpoint:        strings.TrimSuffix(cfg.Endpoint, "/"),
+		headers:         cfg.Headers,
+		gzip:            !cfg.DisableGzip,
+		retryCount:      cfg.RetryCount,
+		backoffInterval: cfg.BackoffInterval,
+		sleep:           time.Sleep,
+	}
+}
+
+// Export posts msg to endpoint+path. Requests failed by network error or status 429/502/503/504 are retried with
+// exponential backoff, Retry-After header of response is respected.
+func (c *Client) Export(path string, msg proto.Message) error {
+	body, err := proto.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if c.gzip {
+		if body, err = httputil.Gzip(body); err != nil {
+			return err
+		}
+	}
+
+	backoff := c.backoffInterval
+	for i := 0; ; i++ {
+		retryAfter, err := c.post(path, body)
+		if err == nil {
+			return nil
+		}
+		if retryAfter < 0 || i >= c.retryCount {
+			return err
+		}
+		if retryAfter == 0 {
+			retryAfter = backoff
+		}
+		c.sleep(retryAfter)
+		if backoff *= 2; backoff > maxBackoffInterval {
+			backoff = maxBackoffInterval
+		}
+	}
+}
+
+// post returns negative duration if request should not be retried
+func (c *Client) post(path string, body []byte) (time.Duration, error) {
+	req, err := http.NewRequest(http.MethodPost, c.endpoint+path, bytes.NewReader(body))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	if c.gzip {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+	err = fmt.Errorf("otlp export to %s failed. status code %d", path, resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return httputil.RetryAfter(resp, maxBackoffInterval), err
+	}
+	return -1, err
+}
+
+// Resource builds resource with string attributes, empty values are ignored
+func Resource(attrs map[string]string) *resourcepb.Resource {
+	return &resourcepb.Resource{Attributes: StringAttributes(attrs)}
+}
+
+// StringAttributes converts map to attributes sorted by key, empty values are ignored
+func StringAttributes(attrs map[string]string) []*commonpb.KeyValue {
+	keys := make([]string, 0, len(attrs))
+	for k, v := range attrs {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	kvs := make([]*commonpb.KeyValue, 0, len(keys))
+	for _, k := range keys {
+		kvs = append(kvs, String(k, attrs[k]))
+	}
+	return kvs
+}
+
+func String(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func Int(key string, value int64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: value}}}
+}
+
+func Double(key string, value float64) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: value}}}
+}
+
+// TraceID decodes 32 hex characters traceId, nil is returned if traceId is invalid
+func TraceID(traceID string) []byte {
+	return decodeID(traceID, 16)
+}
+
+// SpanID decodes spanId into 8 bytes. spanId of aitracer is 32 characters, so only the last 16 characters are used
+func SpanID(spanID string) []byte {
+	return decodeID(spanID, 8)
+}
+
+func decodeID(id string, size int) []byte {
+	if len(id) > size*2 {
+		id = id[len(id)-size*2:]
+	}
+	b, err := hex.DecodeString(id)
+	if err != nil || len(b) != size {
+		return nil
+	}
+	return b
+}
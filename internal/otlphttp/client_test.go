@@ -0,0 +1,61 @@
+package otlphttp
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestClientExport(t *testing.T) {
+	requests := 0
+	var received collectortracepb.ExportTraceServiceRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		assert.Equal(t, TracesPath, r.URL.Path)
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "key", r.Header.Get("X-Api-Key"))
+		gr, err := gzip.NewReader(r.Body)
+		assert.Nil(t, err)
+		body, _ := ioutil.ReadAll(gr)
+		assert.Nil(t, proto.Unmarshal(body, &received))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := NewClient(Config{Endpoint: server.URL + "/", Headers: map[string]string{"X-Api-Key": "key"}})
+	c.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	req := &collectortracepb.ExportTraceServiceRequest{ResourceSpans: []*tracepb.ResourceSpans{{Resource: Resource(map[string]string{"service.name": "svc"})}}}
+	assert.Nil(t, c.Export(TracesPath, req))
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, []time.Duration{2 * time.Second}, slept)
+	assert.Equal(t, "svc", received.ResourceSpans[0].Resource.Attributes[0].Value.GetStringValue())
+
+	// not retryable
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	})
+	requests = 0
+	assert.NotNil(t, c.Export(TracesPath, req))
+	assert.Equal(t, 1, requests)
+}
+
+func TestDecodeID(t *testing.T) {
+	assert.Equal(t, 16, len(TraceID("2023010112000000a1b2c3d4e5f60718")))
+	assert.Equal(t, []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}, SpanID("20230101120000001122334455667788"))
+	assert.Nil(t, SpanID(""))
+}
@@ -0,0 +1,90 @@
+// Package selfstats counts data handled by SDK pipelines, so that data lost by SDK itself can be observed.
+// Counters are process-wide and cumulative, shared by all tracers and metrics clients.
+package selfstats
+
+import "sync/atomic"
+
+const (
+	PipelineTrace  = "trace"
+	PipelineLog    = "log"
+	PipelineMetric = "metric"
+)
+
+var (
+	Trace  = &Pipeline{}
+	Log    = &Pipeline{}
+	Metric = &Pipeline{}
+
+	discard = &Pipeline{}
+)
+
+// Pipeline counts items (traces, logs or metrics) of one pipeline, and connection errors
+type Pipeline struct {
+	enqueued    int64
+	dropped     int64
+	sent        int64
+	encodeError int64
+	connError   int64
+}
+
+type Snapshot struct {
+	Enqueued    int64
+	Dropped     int64
+	Sent        int64
+	EncodeError int64
+	ConnError   int64
+}
+
+// Get returns pipeline by name. Counters of unknown pipeline are discarded
+func Get(name string) *Pipeline {
+	switch name {
+	case PipelineTrace:
+		return Trace
+	case PipelineLog:
+		return Log
+	case PipelineMetric:
+		return Metric
+	}
+	return discard
+}
+
+func (p *Pipeline) AddEnqueued(n int64) {
+	atomic.AddInt64(&p.enqueued, n)
+}
+
+func (p *Pipeline) AddDropped(n int64) {
+	atomic.AddInt64(&p.dropped, n)
+}
+
+func (p *Pipeline) AddSent(n int64) {
+	atomic.AddInt64(&p.sent, n)
+}
+
+func (p *Pipeline) AddEncodeError(n int64) {
+	atomic.AddInt64(&p.encodeError, n)
+}
+
+func (p *Pipeline) AddConnError(n int64) {
+	atomic.AddInt64(&p.connError, n)
+}
+
+func (p *Pipeline) Snapshot() Snapshot {
+	return Snapshot{
+		Enqueued:    atomic.LoadInt64(&p.enqueued),
+		Dropped:     atomic.LoadInt64(&p.dropped),
+		Sent:        atomic.LoadInt64(&p.sent),
+		EncodeError: atomic.LoadInt64(&p.encodeError),
+		ConnError:   atomic.LoadInt64(&p.connError),
+	}
+}
+
+// Sub returns increment from prev
+func (s Snapshot) Sub(prev Snapshot) Snapshot {
+	return Snapshot{
+		Enqueued:    s.Enqueued - prev.Enqueued,
+		Dropped:     s.Dropped - prev.Dropped,
+		Sent:        s.Sent - prev.Sent,
+		EncodeError: s.EncodeError - prev.EncodeError,
+		ConnError:   s.ConnError - prev.ConnError,
+	}
+}
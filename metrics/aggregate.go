@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultHistogramBounds are upper bounds of buckets in 1-2-5 series, which fit latencies in microseconds
+var defaultHistogramBounds = []float64{
+	1, 2, 5, 10, 20, 50, 100, 200, 500,
+	1e3, 2e3, 5e3, 1e4, 2e4, 5e4, 1e5, 2e5, 5e5,
+	1e6, 2e6, 5e6, 1e7,
+}
+
+// histogram counts values in buckets with fixed upper bounds. counts has one more bucket for values greater than all bounds
+type histogram struct {
+	bounds []float64
+	counts []uint64
+	count  uint64
+	sum    float64
+	min    float64
+	max    float64
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+		min:    math.Inf(1),
+		max:    math.Inf(-1),
+	}
+}
+
+func (h *histogram) observe(value float64) {
+	i := sort.SearchFloat64s(h.bounds, value) // the first bound >= value
+	h.counts[i]++
+	h.count++
+	h.sum += value
+	if value < h.min {
+		h.min = value
+	}
+	if value > h.max {
+		h.max = value
+	}
+}
+
+type aggregated struct {
+	mt    uint8
+	name  string
+	tags  []t
+	value float64 // sum of counter, or last value of gauge
+	hist  *histogram
+	epoch uint64 // epoch of aggregator when last updated
+}
+
+// aggregator aggregates metrics of the same type, name and tags in flush interval.
+// counters are summed, the last value of gauges is kept, and timers and histograms are counted in histogram
+type aggregator struct {
+	bounds []float64
+
+	lock    sync.Mutex
+	entries map[string]*aggregated
+	epoch   uint64 // increased by evictIdle
+}
+
+func newAggregator(bounds []float64) *aggregator {
+	return &aggregator{
+		bounds:  bounds,
+		entries: make(map[string]*aggregated),
+	}
+}
+
+// aggregateType returns type of aggregated metric, timers are aggregated as histograms
+func aggregateType(mt uint8) uint8 {
+	if mt == mtTimer {
+		return mtHistogram
+	}
+	return mt
+}
+
+func (a *aggregator) add(mt uint8, name string, value float64, tags []t) {
+	mt = aggregateType(mt)
+	a.addWithKey(aggregateKey(mt, name, tags), mt, name, value, tags)
+}
+
+// addWithKey adds metric with key built by aggregateKey. mt should be converted by aggregateType
+func (a *aggregator) addWithKey(key string, mt uint8, name string, value float64, tags []t) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	e, ok := a.entries[key]
+	if !ok {
+		e = &aggregated{mt: mt, name: name, tags: tags}
+		if mt == mtHistogram {
+			e.hist = newHistogram(a.bounds)
+		}
+		a.entries[key] = e
+	}
+	e.epoch = a.epoch
+	switch mt {
+	case mtCounter:
+		e.value += value
+	case mtGauge:
+		e.value = value
+	case mtHistogram:
+		e.hist.observe(value)
+	}
+}
+
+// collect appends aggregated metrics to items and resets the aggregator
+func (a *aggregator) collect(items []metricItem) []metricItem {
+	a.lock.Lock()
+	entries := a.entries
+	if len(entries) != 0 {
+		a.entries = make(map[string]*aggregated, len(entries))
+	}
+	a.lock.Unlock()
+	for _, e := range entries {
+		item := metricItem{
+			mt:    e.mt,
+			name:  e.name,
+			value: e.value,
+			tags:  e.tags,
+			hist:  e.hist,
+		}
+		if e.hist != nil {
+			item.value = e.hist.sum
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// evictIdle starts a new epoch, and removes entries not updated in the last idleEpochs epochs.
+// It is used by cumulative aggregator, which is never reset, so that series of tags no longer emitted are released
+func (a *aggregator) evictIdle(idleEpochs uint64) int {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.epoch++
+	evicted := 0
+	for key, e := range a.entries {
+		if a.epoch-e.epoch > idleEpochs {
+			delete(a.entries, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// aggregateKey is type, name and tags sorted by key
+func aggregateKey(mt uint8, name string, tags []t) string {
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].key < tags[j].key
+	})
+	var sb strings.Builder
+	sb.WriteByte(mt)
+	sb.WriteString(name)
+	for _, tag := range tags {
+		sb.WriteByte(0)
+		sb.WriteString(tag.key)
+		sb.WriteByte('=')
+		sb.WriteString(tag.value)
+	}
+	return sb.String()
+}
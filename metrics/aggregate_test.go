@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	tagsAB = []t{{"a", "1"}, {"b", "2"}}
+	tagsBA = []t{{"b", "2"}, {"a", "1"}}
+)
+
+func TestAggregator(t *testing.T) {
+	a := newAggregator([]float64{10, 100})
+	a.add(mtCounter, "c", 1, tagsBA)
+	a.add(mtCounter, "c", 2, tagsAB)
+	a.add(mtGauge, "g", 1, nil)
+	a.add(mtGauge, "g", 3, nil)
+	a.add(mtTimer, "h", 5, nil)
+	a.add(mtHistogram, "h", 50, nil)
+	a.add(mtHistogram, "h", 500, nil)
+
+	items := a.collect(nil)
+	assert.Equal(t, 3, len(items))
+	values := make(map[string]metricItem)
+	for _, item := range items {
+		values[item.name] = item
+	}
+	assert.Equal(t, 3.0, values["c"].value)
+	assert.Equal(t, 3.0, values["g"].value)
+	h := values["h"]
+	assert.Equal(t, mtHistogram, h.mt)
+	assert.Equal(t, 555.0, h.value)
+	assert.Equal(t, uint64(3), h.hist.count)
+	assert.Equal(t, []uint64{1, 1, 1}, h.hist.counts)
+	assert.Equal(t, 5.0, h.hist.min)
+	assert.Equal(t, 500.0, h.hist.max)
+
+	assert.Equal(t, 0, len(a.collect(nil))) // reset after collect
+
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(t, formatHistogram(buf, "", "h", h.hist, nil))
+	data := buf.Bytes()
+	// type | name | sum | tags | count | min | max | buckets | 3 * (bound | count)
+	assert.Equal(t, 1+2+8+1+8+8+8+1+3*16, len(data))
+	assert.Equal(t, mtHistogram, data[0])
+	assert.Equal(t, uint8(3), data[36])
+	assert.Equal(t, math.Inf(1), math.Float64frombits(binary.LittleEndian.Uint64(data[len(data)-16:])))
+}
@@ -1,8 +1,14 @@
 package metrics
 
+import "time"
+
 const (
 	maxPacketSize    = 8192
 	batchSize        = 1000
 	asyncChannelSize = 1000
 	asyncWokerNumber = 4
+
+	// series of cumulative aggregator not updated in cumulativeIdleIntervals are evicted, checked every cumulativeEvictInterval
+	cumulativeEvictInterval = time.Minute
+	cumulativeIdleIntervals = 10
 )
@@ -22,6 +22,7 @@ func TestMetrics_WithNewClient(t *testing.T) {
 	_ = client.EmitCounter("example_counter_metric", 1, tags)
 	_ = client.EmitTimer("example_timer_metric", 1000, tags)
 	_ = client.EmitGauge("example_gauge_metric", 100, tags)
+	_ = client.EmitHistogram("example_histogram_metric", 1000, tags)
 
 	client.Close()
 }
@@ -42,6 +43,7 @@ func TestMetrics_WithDefaultClient(t *testing.T) {
 	_ = metrics.EmitCounter("example_counter_metric", 1, tags)
 	_ = metrics.EmitTimer("example_timer_metric", 1000, tags)
 	_ = metrics.EmitGauge("example_gauge_metric", 100, tags)
+	_ = metrics.EmitHistogram("example_histogram_metric", 1000, tags)
 
 	metrics.Close()
 }
@@ -15,6 +15,11 @@ var (
 	mtCounter = uint8(1)
 	mtTimer   = uint8(2)
 	mtGauge   = uint8(3)
+
+	// mtHistogram is followed by common fields with sum as value, and then count, min, max and non-empty buckets:
+	// uint64 count | float64 min | float64 max | uint8 n | n * (float64 upper bound | uint64 count)
+	// upper bound of the last bucket is +Inf
+	mtHistogram = uint8(4)
 )
 
 func formatCommon(buf *bytes.Buffer, mt uint8, prefix string, name string, value float64, tags []t) error {
@@ -34,6 +39,38 @@ func formatCommon(buf *bytes.Buffer, mt uint8, prefix string, name string, value
 	return nil
 }
 
+func formatHistogram(buf *bytes.Buffer, prefix string, name string, h *histogram, tags []t) error {
+	err := formatCommon(buf, mtHistogram, prefix, name, h.sum, tags)
+	if err != nil {
+		return err
+	}
+	writeUint64(buf, h.count)
+	_ = writeFloat64(buf, h.min)
+	_ = writeFloat64(buf, h.max)
+	n := 0
+	for _, c := range h.counts {
+		if c != 0 {
+			n++
+		}
+	}
+	if n > maxStringLen {
+		return errors.New("histogram buckets must less than 256")
+	}
+	buf.WriteByte(uint8(n))
+	for i, c := range h.counts {
+		if c == 0 {
+			continue
+		}
+		bound := math.Inf(1)
+		if i < len(h.bounds) {
+			bound = h.bounds[i]
+		}
+		_ = writeFloat64(buf, bound)
+		writeUint64(buf, c)
+	}
+	return nil
+}
+
 func writeName(buf *bytes.Buffer, prefix string, name string) error {
 	if prefix != "" {
 		length := len(prefix) + len(name) + 1
@@ -83,3 +120,9 @@ func writeFloat64(buf *bytes.Buffer, value float64) error {
 	buf.Write(vv[:])
 	return nil
 }
+
+func writeUint64(buf *bytes.Buffer, value uint64) {
+	vv := [8]byte{}
+	binary.LittleEndian.PutUint64(vv[:], value)
+	buf.Write(vv[:])
+}
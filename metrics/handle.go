@@ -0,0 +1,172 @@
+package metrics
+
+import (
+	"bytes"
+	"sync/atomic"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
+)
+
+// maxHandles limits handles cached by a client. Handles created after that are not interned, and are counted by monitor
+const maxHandles = 10000
+
+// handle is a metric bound to type, name and tags. Encoded bytes of name and tags are cached,
+// so that emitting by handle does not allocate
+type handle struct {
+	mc   *MetricsClient
+	mt   uint8
+	name string
+	tags []t // sorted by key
+
+	key  string // key of aggregator
+	head []byte // encoded type and name, nil if failed to encode
+	tail []byte // encoded tags
+}
+
+// Counter is a counter bound to name and tags, which is safe for concurrent use
+type Counter struct {
+	h *handle
+}
+
+// Add emits value of counter
+func (c *Counter) Add(value float64) {
+	c.h.emit(value)
+}
+
+// Timer is a timer bound to name and tags, which is safe for concurrent use
+type Timer struct {
+	h *handle
+}
+
+// Record emits d in microseconds
+func (tm *Timer) Record(d time.Duration) {
+	tm.h.emit(float64(d.Microseconds()))
+}
+
+// Observe emits value of timer
+func (tm *Timer) Observe(value float64) {
+	tm.h.emit(value)
+}
+
+// Gauge is a gauge bound to name and tags, which is safe for concurrent use
+type Gauge struct {
+	h *handle
+}
+
+// Set emits value of gauge
+func (g *Gauge) Set(value float64) {
+	g.h.emit(value)
+}
+
+// Counter returns the counter of name and tags. Counters of the same name and tags are interned as one,
+// so it is cheap to keep the returned counter and call Add in hot path. At most 10000 handles are interned by a client
+func (mc *MetricsClient) Counter(name string, tags map[string]string) *Counter {
+	return &Counter{h: mc.getHandle(mtCounter, name, tags)}
+}
+
+// Timer returns the timer of name and tags, see Counter
+func (mc *MetricsClient) Timer(name string, tags map[string]string) *Timer {
+	return &Timer{h: mc.getHandle(mtTimer, name, tags)}
+}
+
+// Gauge returns the gauge of name and tags, see Counter
+func (mc *MetricsClient) Gauge(name string, tags map[string]string) *Gauge {
+	return &Gauge{h: mc.getHandle(mtGauge, name, tags)}
+}
+
+func (mc *MetricsClient) getHandle(mt uint8, name string, tags map[string]string) *handle {
+	var ts []t
+	if len(tags) != 0 {
+		ts = make([]t, 0, len(tags))
+		for k, v := range tags {
+			ts = append(ts, t{key: k, value: v})
+		}
+	}
+	key := aggregateKey(aggregateType(mt), name, ts) // sorts ts
+	if h, ok := mc.handles.Load(key); ok {
+		return h.(*handle)
+	}
+	h := &handle{
+		mc:   mc,
+		mt:   mt,
+		name: name,
+		tags: ts,
+		key:  key,
+	}
+	buf := bytes.NewBuffer(nil)
+	buf.WriteByte(mt)
+	if err := writeName(buf, mc.config.prefix, name); err == nil {
+		h.head = append([]byte(nil), buf.Bytes()...)
+		buf.Reset()
+		if err := writeTags(buf, ts); err == nil {
+			h.tail = append([]byte(nil), buf.Bytes()...)
+		} else {
+			h.head = nil
+		}
+	}
+	if atomic.LoadInt64(&mc.handleCount) >= maxHandles {
+		atomic.AddInt64(&mc.monitor.handleCacheFull, 1)
+		return h
+	}
+	actual, loaded := mc.handles.LoadOrStore(key, h)
+	if !loaded {
+		atomic.AddInt64(&mc.handleCount, 1)
+	}
+	return actual.(*handle)
+}
+
+func (h *handle) emit(value float64) {
+	mc := h.mc
+	if mc.cumulative != nil {
+		mc.cumulative.addWithKey(h.key, aggregateType(h.mt), h.name, value, h.tags)
+	}
+	if mc.config.disableSender {
+		return
+	}
+	if mc.config.aggregate {
+		mc.aggregator.addWithKey(h.key, aggregateType(h.mt), h.name, value, h.tags)
+		return
+	}
+	item := metricItem{
+		mt:    h.mt,
+		name:  h.name,
+		value: value,
+		tags:  h.tags,
+		h:     h,
+	}
+	mc.enqueue(item)
+}
+
+// format writes encoded metric with cached bytes, or formats it if failed to encode
+func (h *handle) format(buf *bytes.Buffer, prefix string, value float64) error {
+	if h.head == nil {
+		return formatCommon(buf, h.mt, prefix, h.name, value, h.tags)
+	}
+	buf.Write(h.head)
+	_ = writeFloat64(buf, value)
+	buf.Write(h.tail)
+	return nil
+}
+
+// enqueue appends item to batch, and sends batch to channel if it is full.
+// Items are counted by self stats when batch is taken, so that emit is not contended by a global counter
+func (mc *MetricsClient) enqueue(item metricItem) {
+	var flushBatch *[]metricItem
+	mc.batchLock.Lock()
+	*mc.batchBuf = append(*mc.batchBuf, item)
+	if len(*mc.batchBuf) >= batchSize {
+		flushBatch = mc.batchBuf
+		mc.batchBuf = getMetricItems()
+	}
+	mc.batchLock.Unlock()
+	if flushBatch != nil {
+		selfstats.Metric.AddEnqueued(int64(len(*flushBatch)))
+		select {
+		case mc.dataBuf <- flushBatch:
+		default:
+			atomic.AddInt64(&mc.monitor.metricBufferFull, 1)
+			selfstats.Metric.AddDropped(int64(len(*flushBatch)))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandle(t *testing.T) {
+	mc := NewMetricClient(WithPrefix("prefix"))
+	tags := map[string]string{"b": "2", "a": "1"}
+	c := mc.Counter("c", tags)
+	assert.Equal(t, c.h, mc.Counter("c", map[string]string{"a": "1", "b": "2"}).h) // interned
+	assert.NotEqual(t, c.h, mc.Timer("c", tags).h)
+
+	expected := bytes.NewBuffer(nil)
+	assert.Nil(t, formatCommon(expected, mtCounter, "prefix", "c", 3, c.h.tags))
+	buf := bytes.NewBuffer(nil)
+	assert.Nil(t, c.h.format(buf, "prefix", 3))
+	assert.Equal(t, expected.Bytes(), buf.Bytes())
+
+	tm := mc.Timer("t", tags)
+	allocs := testing.AllocsPerRun(100, func() {
+		c.Add(1)
+		tm.Record(time.Millisecond)
+	})
+	assert.Equal(t, 0.0, allocs)
+	batch := mc.takeBatch()
+	assert.Equal(t, 202, len(*batch))
+	assert.Equal(t, 1000.0, (*batch)[1].value)
+}
+
+func TestHandleAggregation(t *testing.T) {
+	mc := NewMetricClient(WithAggregation(true))
+	c := mc.Counter("c", nil)
+	tm := mc.Timer("t", nil)
+	c.Add(1)
+	tm.Record(time.Millisecond)
+	allocs := testing.AllocsPerRun(100, func() {
+		c.Add(1)
+		tm.Record(time.Millisecond)
+	})
+	assert.Equal(t, 0.0, allocs)
+	items := mc.aggregator.collect(nil)
+	assert.Equal(t, 2, len(items))
+	for _, item := range items {
+		if item.mt == mtCounter {
+			assert.Equal(t, 102.0, item.value)
+		} else {
+			assert.Equal(t, uint64(102), item.hist.count)
+		}
+	}
+}
+
+func TestHandleLimit(t *testing.T) {
+	mc := NewMetricClient()
+	mc.handleCount = maxHandles - 1
+	c := mc.Counter("c", nil)
+	assert.Same(t, c.h, mc.Counter("c", nil).h)
+	assert.Equal(t, int64(maxHandles), mc.handleCount)
+
+	// not interned when cache is full
+	d := mc.Counter("d", nil)
+	assert.NotSame(t, d.h, mc.Counter("d", nil).h)
+	assert.Equal(t, int64(2), mc.monitor.handleCacheFull)
+	_, ok := mc.handles.Load(d.h.key)
+	assert.False(t, ok)
+	d.Add(1)
+	assert.Equal(t, 1, len(*mc.takeBatch()))
+}
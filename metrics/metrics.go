@@ -1,5 +1,7 @@
 package metrics
 
+import "sort"
+
 var (
 	logfunc func(string, ...interface{})
 
@@ -10,6 +12,14 @@ var (
 type Config struct {
 	prefix  string
 	address string
+
+	otlp *OTLPConfig
+
+	aggregate       bool
+	histogramBounds []float64
+
+	prometheus    bool
+	disableSender bool
 }
 
 type ClientOption func(config *Config)
@@ -26,6 +36,50 @@ func WithAddress(address string) ClientOption {
 	}
 }
 
+// WithOTLP exports metrics to OTLP collector by OTLP/HTTP instead of server-agent
+func WithOTLP(otlpConfig OTLPConfig) ClientOption {
+	return func(config *Config) {
+		config.otlp = &otlpConfig
+	}
+}
+
+// WithAggregation aggregates metrics of the same name and tags in client, and sends them every second.
+// counters are summed, the last value of gauges is kept, and timers are sent as histograms
+func WithAggregation(enable bool) ClientOption {
+	return func(config *Config) {
+		config.aggregate = enable
+	}
+}
+
+// WithHistogramBounds sets upper bounds of histogram buckets, values greater than all bounds are counted in an extra bucket.
+// Default bounds are 1, 2, 5, 10, ... up to 1e7, which fit latencies in microseconds
+func WithHistogramBounds(bounds []float64) ClientOption {
+	return func(config *Config) {
+		if len(bounds) == 0 {
+			return
+		}
+		sorted := make([]float64, len(bounds))
+		copy(sorted, bounds)
+		sort.Float64s(sorted)
+		config.histogramBounds = sorted
+	}
+}
+
+// WithPrometheus keeps cumulative values of metrics in client, which are exposed by PrometheusHandler
+func WithPrometheus(enable bool) ClientOption {
+	return func(config *Config) {
+		config.prometheus = enable
+	}
+}
+
+// WithSender enables sending metrics to server-agent or OTLP collector, which is enabled by default.
+// Disable it to expose metrics only by PrometheusHandler where server-agent does not exist
+func WithSender(enable bool) ClientOption {
+	return func(config *Config) {
+		config.disableSender = !enable
+	}
+}
+
 func Init(options ...ClientOption) {
 	defaultMetricClient = NewMetricClient(options...)
 	defaultMetricClient.Start()
@@ -59,3 +113,7 @@ func EmitTimer(name string, value float64, tags map[string]string) error {
 func EmitGauge(name string, value float64, tags map[string]string) error {
 	return defaultMetricClient.EmitGauge(name, value, tags)
 }
+
+func EmitHistogram(name string, value float64, tags map[string]string) error {
+	return defaultMetricClient.EmitHistogram(name, value, tags)
+}
@@ -2,13 +2,22 @@ package metrics
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"os"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/flush"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
 )
 
+var ErrorClientClosed = errors.New("metrics client closed")
+
 type MetricsClient struct {
+	handleCount int64 // number of handles cached, first for 64-bit alignment of atomic operations
+
 	monitor *monitor
 
 	config  Config
@@ -18,14 +27,24 @@ type MetricsClient struct {
 	batchBuf  *[]metricItem
 	batchLock sync.Mutex
 
+	aggregator *aggregator
+	handles    sync.Map    // aggregate key -> *handle
+	cumulative *aggregator // never reset, set if prometheus is enabled
+
 	flusherStop chan struct{}
 	flusherWg   sync.WaitGroup
 	senderWg    sync.WaitGroup
+
+	flushReqs *flush.Requests
+
+	closeLock sync.RWMutex // protects dataBuf from being written by Flush after closed
+	closed    bool
 }
 
 func NewMetricClient(options ...ClientOption) *MetricsClient {
 	config := Config{
-		address: defaultAddress,
+		address:         defaultAddress,
+		histogramBounds: defaultHistogramBounds,
 	}
 	envAddress := os.Getenv("AI_METRICS_SOCK")
 	if len(envAddress) != 0 {
@@ -40,7 +59,13 @@ func NewMetricClient(options ...ClientOption) *MetricsClient {
 		dataBuf:  make(chan *[]metricItem, asyncChannelSize),
 		batchBuf: getMetricItems(),
 
+		aggregator: newAggregator(config.histogramBounds),
+
 		flusherStop: make(chan struct{}),
+		flushReqs:   flush.NewRequests(),
+	}
+	if config.prometheus {
+		mc.cumulative = newAggregator(config.histogramBounds)
 	}
 	return mc
 }
@@ -67,6 +92,7 @@ func (mc *MetricsClient) Start() {
 }
 
 func (mc *MetricsClient) Close() {
+	mc.setClosed()
 	close(mc.flusherStop)
 	mc.flusherWg.Wait()
 
@@ -76,6 +102,65 @@ func (mc *MetricsClient) Close() {
 	mc.monitor.stop()
 }
 
+// CloseWithContext is like Close, but gives up waiting for senders when ctx is done, and returns the number of metrics
+// left in channel, which are dropped. Metrics in packets being sent are not counted
+func (mc *MetricsClient) CloseWithContext(ctx context.Context) (int, error) {
+	mc.setClosed()
+	err := flush.Wait(ctx, func() {
+		close(mc.flusherStop)
+		mc.flusherWg.Wait()
+
+		close(mc.dataBuf)
+		mc.senderWg.Wait()
+	})
+	mc.monitor.stop()
+	if err == nil {
+		return 0, nil
+	}
+	lost := 0
+	for drained := false; !drained; {
+		select {
+		case items, ok := <-mc.dataBuf:
+			if !ok {
+				drained = true
+			} else if items != nil {
+				lost += len(*items)
+				putMetricItems(items)
+			}
+		default:
+			drained = true
+		}
+	}
+	selfstats.Metric.AddDropped(int64(lost))
+	return lost, err
+}
+
+func (mc *MetricsClient) setClosed() {
+	mc.closeLock.Lock()
+	mc.closed = true
+	mc.closeLock.Unlock()
+}
+
+// Flush sends metrics batched and queued, and waits for them to be sent until ctx is done. It should be called after Start,
+// and ErrorClientClosed is returned after closed
+func (mc *MetricsClient) Flush(ctx context.Context) error {
+	mc.closeLock.RLock()
+	defer mc.closeLock.RUnlock()
+	if mc.closed {
+		return ErrorClientClosed
+	}
+	flushBatch := mc.takeBatch()
+	if flushBatch != nil {
+		select {
+		case mc.dataBuf <- flushBatch:
+		case <-ctx.Done():
+			selfstats.Metric.AddDropped(int64(len(*flushBatch)))
+			return ctx.Err()
+		}
+	}
+	return mc.flushReqs.Flush(ctx, asyncWokerNumber)
+}
+
 func (mc *MetricsClient) EmitCounter(name string, value float64, tags map[string]string) error {
 	return mc.emitMetric(mtCounter, name, value, tags)
 }
@@ -88,6 +173,12 @@ func (mc *MetricsClient) EmitGauge(name string, value float64, tags map[string]s
 	return mc.emitMetric(mtGauge, name, value, tags)
 }
 
+// EmitHistogram counts value in buckets of histogram. Histograms are aggregated by name and tags in client,
+// and sent every second with count, sum, min, max and bucket counts
+func (mc *MetricsClient) EmitHistogram(name string, value float64, tags map[string]string) error {
+	return mc.emitMetric(mtHistogram, name, value, tags)
+}
+
 func (mc *MetricsClient) emitMetric(mt uint8, name string, value float64, tags map[string]string) error {
 	item := metricItem{
 		mt:    mt,
@@ -101,21 +192,18 @@ func (mc *MetricsClient) emitMetric(mt uint8, name string, value float64, tags m
 		}
 	}
 
-	var flushBatch *[]metricItem
-	mc.batchLock.Lock()
-	*mc.batchBuf = append(*mc.batchBuf, item)
-	if len(*mc.batchBuf) >= batchSize {
-		flushBatch = mc.batchBuf
-		mc.batchBuf = getMetricItems()
+	if mc.cumulative != nil {
+		mc.cumulative.add(mt, name, value, item.tags)
 	}
-	mc.batchLock.Unlock()
-	if flushBatch != nil {
-		select {
-		case mc.dataBuf <- flushBatch:
-		default:
-			atomic.AddInt64(&mc.monitor.metricBufferFull, 1)
-		}
+	if mc.config.disableSender {
+		return nil
 	}
+	if mt == mtHistogram || mc.config.aggregate {
+		mc.aggregator.add(mt, name, value, item.tags)
+		return nil
+	}
+
+	mc.enqueue(item)
 	return nil
 }
 
@@ -124,10 +212,18 @@ func (mc *MetricsClient) batchFlushLoop() {
 	defer func() {
 		ticker.Stop()
 	}()
+	var evictC <-chan time.Time // nil if prometheus is disabled
+	if mc.cumulative != nil {
+		evictTicker := time.NewTicker(cumulativeEvictInterval)
+		defer evictTicker.Stop()
+		evictC = evictTicker.C
+	}
 	for {
 		select {
 		case <-ticker.C:
 			mc.batchFlush()
+		case <-evictC:
+			mc.cumulative.evictIdle(cumulativeIdleIntervals)
 		case <-mc.flusherStop:
 			mc.batchFlush()
 			return
@@ -135,14 +231,24 @@ func (mc *MetricsClient) batchFlushLoop() {
 	}
 }
 
-func (mc *MetricsClient) batchFlush() {
+// takeBatch returns metrics batched and aggregated, or nil if there is none
+func (mc *MetricsClient) takeBatch() *[]metricItem {
 	var flushBatch *[]metricItem
 	mc.batchLock.Lock()
+	*mc.batchBuf = mc.aggregator.collect(*mc.batchBuf)
 	if len(*mc.batchBuf) != 0 {
 		flushBatch = mc.batchBuf
 		mc.batchBuf = getMetricItems()
 	}
 	mc.batchLock.Unlock()
+	if flushBatch != nil {
+		selfstats.Metric.AddEnqueued(int64(len(*flushBatch)))
+	}
+	return flushBatch
+}
+
+func (mc *MetricsClient) batchFlush() {
+	flushBatch := mc.takeBatch()
 	if flushBatch != nil {
 		mc.dataBuf <- flushBatch
 	}
@@ -150,42 +256,88 @@ func (mc *MetricsClient) batchFlush() {
 }
 
 func (mc *MetricsClient) sendLoop() {
+	if mc.config.otlp != nil {
+		mc.otlpSendLoop()
+		return
+	}
 	sender := newSender(mc.config.address, mc.monitor)
 	packetBuf := make([]byte, 0, maxPacketSize)
 	itemBuf := bytes.NewBuffer(nil)
 
 	prefix := mc.config.prefix
-	for items := range mc.dataBuf {
+	packetItems := 0 // number of metrics in packetBuf
+	handle := func(items *[]metricItem) {
 		if items != nil {
 			for _, item := range *items {
 				itemBuf.Reset()
-				err := formatCommon(itemBuf, item.mt, prefix, item.name, item.value, item.tags)
+				var err error
+				if item.mt == mtHistogram {
+					err = formatHistogram(itemBuf, prefix, item.name, item.hist, item.tags)
+				} else if item.h != nil {
+					err = item.h.format(itemBuf, prefix, item.value)
+				} else {
+					err = formatCommon(itemBuf, item.mt, prefix, item.name, item.value, item.tags)
+				}
 				if err != nil {
 					atomic.AddInt64(&mc.monitor.formatError, 1)
+					selfstats.Metric.AddEncodeError(1)
 					continue
 				}
 				data := itemBuf.Bytes()
 				if len(packetBuf)+len(data) <= maxPacketSize {
 					packetBuf = append(packetBuf, data...)
+					packetItems++
 				} else {
 					if len(data) > maxPacketSize {
-						sender.SendPacket(data)
+						sender.SendPacket(data, 1)
 					} else {
-						sender.SendPacket(packetBuf)
+						sender.SendPacket(packetBuf, packetItems)
 						packetBuf = packetBuf[:0]
 						packetBuf = append(packetBuf, data...)
+						packetItems = 1
 					}
 				}
 			}
 			putMetricItems(items)
 		} else {
 			if len(packetBuf) != 0 {
-				sender.SendPacket(packetBuf)
+				sender.SendPacket(packetBuf, packetItems)
 				packetBuf = packetBuf[:0]
+				packetItems = 0
 			}
 		}
 	}
+	for mc.receive(handle) {
+	}
 	if len(packetBuf) != 0 {
-		sender.SendPacket(packetBuf)
+		sender.SendPacket(packetBuf, packetItems)
+	}
+}
+
+// receive passes next batch in dataBuf to handle, and returns false if dataBuf is closed.
+// For flush request, batches queued in dataBuf are passed and then a nil batch to send buffered packet.
+func (mc *MetricsClient) receive(handle func(items *[]metricItem)) bool {
+	select {
+	case items, ok := <-mc.dataBuf:
+		if !ok {
+			return false
+		}
+		handle(items)
+	case b := <-mc.flushReqs.C:
+		for drained := false; !drained; {
+			select {
+			case items, ok := <-mc.dataBuf:
+				if !ok {
+					drained = true
+					break
+				}
+				handle(items)
+			default:
+				drained = true
+			}
+		}
+		handle(nil)
+		b.Done()
 	}
+	return true
 }
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlushAfterClose(t *testing.T) {
+	mc := NewMetricClient(WithAddress(filepath.Join(t.TempDir(), "metrics.sock")))
+	mc.Start()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.Nil(t, mc.EmitCounter("c", 1, nil))
+	assert.Nil(t, mc.Flush(ctx))
+
+	mc.Close()
+	assert.Nil(t, mc.EmitCounter("c", 1, nil))
+	assert.Equal(t, ErrorClientClosed, mc.Flush(ctx)) // batch is not sent to closed channel
+}
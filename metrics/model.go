@@ -10,4 +10,6 @@ type metricItem struct {
 	name  string
 	value float64
 	tags  []t
+	hist  *histogram // set for mtHistogram
+	h     *handle    // set if emitted by handle
 }
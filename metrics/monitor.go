@@ -11,6 +11,7 @@ type monitor struct {
 	senderDialError  int64
 	senderWriteError int64
 	formatError      int64
+	handleCacheFull  int64
 
 	stopChan chan struct{}
 	wg       sync.WaitGroup
@@ -59,6 +60,7 @@ func (m *monitor) showAndReset() {
 		{v: &m.senderWriteError, log: "sender write error"},
 		{v: &m.senderDialError, log: "sender dial error"},
 		{v: &m.formatError, log: "format error"},
+		{v: &m.handleCacheFull, log: "handle cache full"},
 	} {
 		cv := atomic.SwapInt64(i.v, 0)
 		if cv != 0 && logfunc != nil {
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/otlphttp"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+const otlpScopeName = "apminsight-server-sdk-go"
+
+type OTLPConfig struct {
+	Endpoint string // base url of collector, like http://localhost:4318
+	Headers  map[string]string
+
+	DisableGzip bool
+	Timeout     time.Duration
+
+	RetryCount      int
+	BackoffInterval time.Duration // initial backoff, doubled after each retry
+
+	ResourceAttributes map[string]string // like service.name
+}
+
+// otlpSendLoop exports each batch as one request. counter is exported as delta sum,
+// timer as delta histogram without buckets, histogram as delta histogram with explicit bounds, and gauge as gauge.
+func (mc *MetricsClient) otlpSendLoop() {
+	cfg := mc.config.otlp
+	client := otlphttp.NewClient(otlphttp.Config{
+		Endpoint:        cfg.Endpoint,
+		Headers:         cfg.Headers,
+		DisableGzip:     cfg.DisableGzip,
+		Timeout:         cfg.Timeout,
+		RetryCount:      cfg.RetryCount,
+		BackoffInterval: cfg.BackoffInterval,
+	})
+	resource := otlphttp.Resource(cfg.ResourceAttributes)
+	handle := func(items *[]metricItem) {
+		if items == nil {
+			return
+		}
+		req := toOTLPMetrics(*items, mc.config.prefix, time.Now())
+		count := int64(len(*items))
+		putMetricItems(items)
+		req.ResourceMetrics[0].Resource = resource
+		if err := client.Export(otlphttp.MetricsPath, req); err != nil {
+			atomic.AddInt64(&mc.monitor.senderWriteError, 1)
+			selfstats.Metric.AddConnError(1)
+			selfstats.Metric.AddDropped(count)
+			if logfunc != nil {
+				logfunc("export otlp metrics err %v", err)
+			}
+			return
+		}
+		selfstats.Metric.AddSent(count)
+	}
+	for mc.receive(handle) {
+	}
+}
+
+type otlpMetricKey struct {
+	mt   uint8
+	name string
+}
+
+func toOTLPMetrics(items []metricItem, prefix string, now time.Time) *collectormetricspb.ExportMetricsServiceRequest {
+	scopeMetrics := &metricspb.ScopeMetrics{Scope: &commonpb.InstrumentationScope{Name: otlpScopeName}}
+	index := make(map[otlpMetricKey]*metricspb.Metric)
+	ts := uint64(now.UnixNano())
+	for _, item := range items {
+		name := item.name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		key := otlpMetricKey{mt: item.mt, name: name}
+		m, ok := index[key]
+		if !ok {
+			m = &metricspb.Metric{Name: name}
+			switch item.mt {
+			case mtCounter:
+				m.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+					IsMonotonic:            true,
+				}}
+			case mtTimer, mtHistogram:
+				m.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+					AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA,
+				}}
+			case mtGauge:
+				m.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{}}
+			default:
+				continue
+			}
+			index[key] = m
+			scopeMetrics.Metrics = append(scopeMetrics.Metrics, m)
+		}
+
+		attrs := make([]*commonpb.KeyValue, 0, len(item.tags))
+		for _, tag := range item.tags {
+			attrs = append(attrs, otlphttp.String(tag.key, tag.value))
+		}
+		switch data := m.Data.(type) {
+		case *metricspb.Metric_Sum:
+			data.Sum.DataPoints = append(data.Sum.DataPoints, &metricspb.NumberDataPoint{
+				Attributes:   attrs,
+				TimeUnixNano: ts,
+				Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: item.value},
+			})
+		case *metricspb.Metric_Gauge:
+			data.Gauge.DataPoints = append(data.Gauge.DataPoints, &metricspb.NumberDataPoint{
+				Attributes:   attrs,
+				TimeUnixNano: ts,
+				Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: item.value},
+			})
+		case *metricspb.Metric_Histogram:
+			sum := item.value
+			if h := item.hist; h != nil {
+				data.Histogram.DataPoints = append(data.Histogram.DataPoints, &metricspb.HistogramDataPoint{
+					Attributes:     attrs,
+					TimeUnixNano:   ts,
+					Count:          h.count,
+					Sum:            &sum,
+					BucketCounts:   h.counts,
+					ExplicitBounds: h.bounds,
+				})
+				continue
+			}
+			data.Histogram.DataPoints = append(data.Histogram.DataPoints, &metricspb.HistogramDataPoint{
+				Attributes:   attrs,
+				TimeUnixNano: ts,
+				Count:        1,
+				Sum:          &sum,
+				BucketCounts: []uint64{1},
+			})
+		}
+	}
+	return &collectormetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{ScopeMetrics: []*metricspb.ScopeMetrics{scopeMetrics}}},
+	}
+}
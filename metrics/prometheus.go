@@ -0,0 +1,208 @@
+package metrics
+
+import (
+	"bufio"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const prometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// snapshot returns copies of aggregated metrics, which are not reset
+func (a *aggregator) snapshot() []aggregated {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	entries := make([]aggregated, 0, len(a.entries))
+	for _, e := range a.entries {
+		c := *e
+		if e.hist != nil {
+			h := *e.hist
+			h.counts = append([]uint64(nil), e.hist.counts...)
+			c.hist = &h
+		}
+		entries = append(entries, c)
+	}
+	return entries
+}
+
+type prometheusSeries struct {
+	labels string
+	metric aggregated
+}
+
+type prometheusFamily struct {
+	name   string
+	mt     uint8
+	series []prometheusSeries
+}
+
+// PrometheusHandler returns an http.Handler which exposes metrics emitted by clients in Prometheus text format.
+// clients should be created with WithPrometheus(true). Counters are exposed as cumulative counters, gauges as gauges,
+// and timers and histograms as cumulative histograms. Names and tag keys are sanitized, like a.b-c to a_b_c.
+// Only the first tag in key order is kept if tag keys are sanitized to the same name. Series not updated in 10 minutes are removed
+func PrometheusHandler(clients ...*MetricsClient) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", prometheusContentType)
+		bw := bufio.NewWriter(w)
+		writePrometheus(bw, clients)
+		_ = bw.Flush()
+	})
+}
+
+func writePrometheus(w *bufio.Writer, clients []*MetricsClient) {
+	families := make(map[string]*prometheusFamily)
+	for _, mc := range clients {
+		if mc == nil || mc.cumulative == nil {
+			continue
+		}
+		for _, m := range mc.cumulative.snapshot() {
+			name := mc.config.prefix + "." + m.name
+			if mc.config.prefix == "" {
+				name = m.name
+			}
+			name = sanitizePrometheusName(name)
+			f, ok := families[name]
+			if !ok {
+				f = &prometheusFamily{name: name, mt: m.mt}
+				families[name] = f
+			}
+			if f.mt != m.mt { // conflicted type of the same name
+				continue
+			}
+			f.series = append(f.series, prometheusSeries{labels: prometheusLabels(m.tags), metric: m})
+		}
+	}
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := families[name]
+		sort.Slice(f.series, func(i, j int) bool {
+			return f.series[i].labels < f.series[j].labels
+		})
+		series := f.series[:0]
+		for i, s := range f.series { // series may have the same labels after sanitized, only the first is kept
+			if i == 0 || s.labels != f.series[i-1].labels {
+				series = append(series, s)
+			}
+		}
+		f.series = series
+		writePrometheusFamily(w, f)
+	}
+}
+
+func writePrometheusFamily(w *bufio.Writer, f *prometheusFamily) {
+	typ := "gauge"
+	switch f.mt {
+	case mtCounter:
+		typ = "counter"
+	case mtHistogram:
+		typ = "histogram"
+	}
+	w.WriteString("# TYPE " + f.name + " " + typ + "\n")
+	for _, s := range f.series {
+		if f.mt != mtHistogram {
+			writePrometheusSample(w, f.name, s.labels, "", s.metric.value)
+			continue
+		}
+		h := s.metric.hist
+		var cumulative uint64
+		for i, c := range h.counts {
+			cumulative += c
+			le := "+Inf"
+			if i < len(h.bounds) {
+				le = formatPrometheusFloat(h.bounds[i])
+			}
+			writePrometheusSample(w, f.name+"_bucket", s.labels, `le="`+le+`"`, float64(cumulative))
+		}
+		writePrometheusSample(w, f.name+"_sum", s.labels, "", h.sum)
+		writePrometheusSample(w, f.name+"_count", s.labels, "", float64(h.count))
+	}
+}
+
+func writePrometheusSample(w *bufio.Writer, name, labels, extra string, value float64) {
+	w.WriteString(name)
+	if labels != "" || extra != "" {
+		w.WriteByte('{')
+		w.WriteString(labels)
+		if labels != "" && extra != "" {
+			w.WriteByte(',')
+		}
+		w.WriteString(extra)
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatPrometheusFloat(value))
+	w.WriteByte('\n')
+}
+
+// prometheusLabels formats tags sorted by key as k1="v1",k2="v2". Tags whose keys are sanitized to a name
+// of previous tags are skipped
+func prometheusLabels(tags []t) string {
+	sorted := make([]t, len(tags))
+	copy(sorted, tags)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].key < sorted[j].key
+	})
+	var sb strings.Builder
+	seen := make(map[string]bool, len(sorted))
+	for _, tag := range sorted {
+		key := sanitizePrometheusName(tag.key)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if sb.Len() > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(key)
+		sb.WriteString(`="`)
+		sb.WriteString(escapePrometheusLabelValue(tag.value))
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
+
+// sanitizePrometheusName replaces characters not in [a-zA-Z0-9_] with '_', and prefixes '_' if name starts with digit
+func sanitizePrometheusName(name string) string {
+	var sb strings.Builder
+	sb.Grow(len(name) + 1)
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+			sb.WriteByte(c)
+		case c >= '0' && c <= '9':
+			if i == 0 {
+				sb.WriteByte('_')
+			}
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+var prometheusLabelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapePrometheusLabelValue(value string) string {
+	return prometheusLabelValueReplacer.Replace(value)
+}
+
+func formatPrometheusFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusHandler(t *testing.T) {
+	mc := NewMetricClient(WithPrefix("app"), WithPrometheus(true), WithSender(false), WithHistogramBounds([]float64{10, 100}))
+	tags := map[string]string{"resource": `/a"b`, "status-code": "0"}
+	_ = mc.EmitCounter("req.throughput", 1, tags)
+	mc.Counter("req.throughput", tags).Add(2)
+	_ = mc.EmitGauge("9goroutines", 5, nil)
+	_ = mc.EmitTimer("req.latency", 50, nil)
+	_ = mc.EmitTimer("req.latency", 500, nil)
+	assert.Nil(t, mc.takeBatch()) // not sent
+
+	rec := httptest.NewRecorder()
+	PrometheusHandler(mc, nil).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+	assert.Equal(t, "_9a", sanitizePrometheusName("9a"))
+	assert.Equal(t, prometheusContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, `# TYPE app_9goroutines gauge
+app_9goroutines 5
+# TYPE app_req_latency histogram
+app_req_latency_bucket{le="10"} 0
+app_req_latency_bucket{le="100"} 1
+app_req_latency_bucket{le="+Inf"} 2
+app_req_latency_sum 550
+app_req_latency_count 2
+# TYPE app_req_throughput counter
+app_req_throughput{resource="/a\"b",status_code="0"} 3
+`, string(body))
+}
+
+func TestPrometheusCollision(t *testing.T) {
+	mc := NewMetricClient(WithPrometheus(true), WithSender(false))
+	_ = mc.EmitGauge("g", 1, map[string]string{"a.b": "1", "a_b": "2"}) // a.b is kept
+	_ = mc.EmitGauge("g", 2, map[string]string{"a-b": "3", "a.b": "1"}) // a-b is kept
+	_ = mc.EmitGauge("g", 3, map[string]string{"a.b": "1"})             // the same labels as the first one
+	rec := httptest.NewRecorder()
+	PrometheusHandler(mc).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := ioutil.ReadAll(rec.Body)
+	assert.True(t, strings.HasPrefix(string(body), "# TYPE g gauge\n"))
+	assert.Equal(t, 1, strings.Count(string(body), `g{a_b="1"}`))
+	assert.Contains(t, string(body), `g{a_b="3"} 2`)
+	assert.Equal(t, 3, strings.Count(string(body), "\n"))
+}
+
+func TestCumulativeEviction(t *testing.T) {
+	mc := NewMetricClient(WithPrometheus(true), WithSender(false))
+	_ = mc.EmitCounter("idle", 1, nil)
+	_ = mc.EmitCounter("active", 1, nil)
+	for i := 0; i < cumulativeIdleIntervals; i++ {
+		assert.Equal(t, 0, mc.cumulative.evictIdle(cumulativeIdleIntervals))
+		_ = mc.EmitCounter("active", 1, nil)
+	}
+	assert.Equal(t, 1, mc.cumulative.evictIdle(cumulativeIdleIntervals))
+	entries := mc.cumulative.snapshot()
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, "active", entries[0].name)
+	assert.Equal(t, float64(cumulativeIdleIntervals+1), entries[0].value) // cumulated value is kept
+}
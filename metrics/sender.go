@@ -3,6 +3,8 @@ package metrics
 import (
 	"net"
 	"sync/atomic"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
 )
 
 type sender struct {
@@ -18,12 +20,15 @@ func newSender(address string, monitor *monitor) *sender {
 	}
 }
 
-func (s *sender) SendPacket(packet []byte) {
+// SendPacket sends packet containing items metrics
+func (s *sender) SendPacket(packet []byte, items int) {
 	if s.conn == nil {
 		var err error
 		s.conn, err = net.Dial("unixgram", s.address)
 		if err != nil {
 			atomic.AddInt64(&s.monitor.senderDialError, 1)
+			selfstats.Metric.AddConnError(1)
+			selfstats.Metric.AddDropped(int64(items))
 			if logfunc != nil {
 				logfunc("dial address %s err %v", s.address, err)
 			}
@@ -34,10 +39,12 @@ func (s *sender) SendPacket(packet []byte) {
 	if err != nil {
 		s.conn = nil
 		atomic.AddInt64(&s.monitor.senderWriteError, 1)
+		selfstats.Metric.AddConnError(1)
+		selfstats.Metric.AddDropped(int64(items))
 		if logfunc != nil {
 			logfunc("write conn packet %d bytes err %v", len(packet), err)
 		}
 		return
 	}
-
+	selfstats.Metric.AddSent(int64(items))
 }
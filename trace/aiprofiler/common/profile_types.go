@@ -8,10 +8,13 @@ const (
 	ProfileTypeBlock     ProfileType = "block"
 	ProfileTypeMutex     ProfileType = "mutex"
 	ProfileTypeGoroutine ProfileType = "goroutine"
+
+	ProfileTypeExecutionTrace ProfileType = "trace" // execution trace of runtime/trace, identical to net/http/pprof
 )
 
 var validProfileTypes = map[ProfileType]struct{}{
 	ProfileTypeCPU: {}, ProfileTypeHeap: {}, ProfileTypeBlock: {}, ProfileTypeMutex: {}, ProfileTypeGoroutine: {},
+	ProfileTypeExecutionTrace: {},
 }
 
 func (pt ProfileType) ToString() string {
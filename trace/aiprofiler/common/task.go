@@ -8,7 +8,10 @@ type Task struct {
 	PtConfigs         []ProfileTypeConfig
 	StartTimeMilliSec int64
 	EndTimeMilliSec   int64
-	UseCache          bool // use cache to compute delta. only continuous profile support this option
+	UseCache          bool   // use cache to compute delta. only continuous profile support this option
+	DumpRecent        bool   // upload profiles recorded by flight recorder before the task. only conditional profile support this option
+	TriggerUploadID   string // UploadID of the task which dumps recent profiles, set for recorded profiles
+	Reason            string // why the task is triggered, set for tasks of Profiler.TriggerProfile
 }
 
 func (t *Task) FindConfig(pt ProfileType) (ProfileTypeConfig, bool) {
@@ -0,0 +1,101 @@
+package aiprofiler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+)
+
+const (
+	controlPathTrigger  = "/trigger"
+	controlPathProfiles = "/profiles"
+
+	maxTriggerRequestBytes = 64 << 10
+)
+
+type triggerRequest struct {
+	ProfileTypes    []string `json:"profile_types"`
+	DurationSeconds int64    `json:"duration_seconds"`
+	Reason          string   `json:"reason"`
+}
+
+type triggerResponse struct {
+	UploadID string `json:"upload_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ControlHandler returns an http.Handler to control profiler, which can be mounted at any prefix. Requests must carry
+// header "Authorization: Bearer <token>", and all requests are rejected if token is empty.
+//   - POST .../trigger with body {"profile_types":["cpu","heap"],"duration_seconds":30,"reason":"slo alert"} calls TriggerProfile and
+//     responds {"upload_id":"..."}
+//   - GET .../profiles responds active profiles from remote settings, grouped by record type
+func (p *Profiler) ControlHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeJSON(w, http.StatusUnauthorized, triggerResponse{Error: "unauthorized"})
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, controlPathTrigger):
+			if r.Method != http.MethodPost {
+				writeJSON(w, http.StatusMethodNotAllowed, triggerResponse{Error: "method not allowed"})
+				return
+			}
+			p.handleTrigger(w, r)
+		case strings.HasSuffix(r.URL.Path, controlPathProfiles):
+			if r.Method != http.MethodGet {
+				writeJSON(w, http.StatusMethodNotAllowed, triggerResponse{Error: "method not allowed"})
+				return
+			}
+			writeJSON(w, http.StatusOK, p.manager.ActiveProfiles())
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+func (p *Profiler) handleTrigger(w http.ResponseWriter, r *http.Request) {
+	var req triggerRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxTriggerRequestBytes)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, triggerResponse{Error: err.Error()})
+		return
+	}
+	types := make([]common.ProfileType, 0, len(req.ProfileTypes))
+	for _, s := range req.ProfileTypes {
+		types = append(types, common.ProfileType(s))
+	}
+	uploadID, err := p.TriggerProfile(r.Context(), types, time.Duration(req.DurationSeconds)*time.Second, req.Reason)
+	switch {
+	case err == nil:
+		writeJSON(w, http.StatusOK, triggerResponse{UploadID: uploadID})
+	case errors.Is(err, ErrorUnknownProfile), errors.Is(err, ErrorNoProfileType):
+		writeJSON(w, http.StatusBadRequest, triggerResponse{Error: err.Error()})
+	case errors.Is(err, ErrorTriggerTooFrequent):
+		writeJSON(w, http.StatusTooManyRequests, triggerResponse{Error: err.Error()})
+	default: // stopped, task queue is full or request is canceled
+		writeJSON(w, http.StatusServiceUnavailable, triggerResponse{Error: err.Error()})
+	}
+}
+
+func authorized(r *http.Request, token string) bool {
+	if token == "" {
+		return false
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) == 1
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
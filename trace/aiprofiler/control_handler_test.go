@@ -0,0 +1,91 @@
+package aiprofiler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+)
+
+func TestTriggerProfile(t *testing.T) {
+	p := NewProfiler("http", "trigger_profile")
+	ctx := context.Background()
+
+	_, err := p.TriggerProfile(ctx, nil, 0, "")
+	assert.Equal(t, ErrorNoProfileType, err)
+	_, err = p.TriggerProfile(ctx, []common.ProfileType{"unknown"}, 0, "")
+	assert.True(t, errors.Is(err, ErrorUnknownProfile))
+
+	uploadID, err := p.TriggerProfile(ctx, []common.ProfileType{common.ProfileTypeCPU, common.ProfileTypeGoroutine}, time.Hour, "slo alert")
+	assert.Nil(t, err)
+	task := <-p.taskChan
+	assert.Equal(t, uploadID, task.UploadID)
+	assert.Equal(t, "slo alert", task.Reason)
+	cpu, _ := task.FindConfig(common.ProfileTypeCPU)
+	assert.Equal(t, int64(maxTriggerDuration/time.Second), cpu.DurationSeconds)
+	goroutine, _ := task.FindConfig(common.ProfileTypeGoroutine)
+	assert.True(t, goroutine.IsSnapshot)
+	_, err = p.TriggerProfile(ctx, []common.ProfileType{common.ProfileTypeHeap}, 0, "")
+	assert.Equal(t, ErrorTriggerTooFrequent, err)
+	p.lastTriggerTime = time.Time{}
+
+	h := p.ControlHandler("secret")
+	serve := func(method, path, token, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/profiler/profiles", "", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "/profiler/profiles", "wrong", "").Code)
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/profiler/profiles", nil)
+	r.Header.Set("Authorization", "Bearer ")
+	p.ControlHandler("").ServeHTTP(w, r) // empty token rejects all
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	w = serve(http.MethodGet, "/profiler/profiles", "secret", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"conditional":[]`)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodGet, "/profiler/trigger", "secret", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/profiler/trigger", "secret", `{"profile_types":["unknown"]}`).Code)
+	w = serve(http.MethodPost, "/profiler/trigger", "secret", `{"profile_types":["heap"],"duration_seconds":30,"reason":"on-call"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp triggerResponse
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	task = <-p.taskChan
+	assert.Equal(t, resp.UploadID, task.UploadID)
+	assert.Equal(t, "on-call", task.Reason)
+	heap, _ := task.FindConfig(common.ProfileTypeHeap)
+	assert.Equal(t, int64(30), heap.DurationSeconds)
+
+	assert.Equal(t, http.StatusTooManyRequests, serve(http.MethodPost, "/profiler/trigger", "secret", `{"profile_types":["heap"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(http.MethodPost, "/profiler/trigger", "secret", `{"reason":"`+strings.Repeat("a", maxTriggerRequestBytes)+`"}`).Code)
+
+	// task queue is full
+	for i := 0; i < cap(p.taskChan); i++ {
+		p.taskChan <- &common.Task{}
+	}
+	p.lastTriggerTime = time.Time{}
+	_, err = p.TriggerProfile(ctx, []common.ProfileType{common.ProfileTypeHeap}, 0, "")
+	assert.Equal(t, ErrorProfilerBusy, err)
+	for i := 0; i < cap(p.taskChan); i++ {
+		<-p.taskChan
+	}
+
+	p.closeTaskChan()
+	_, err = p.TriggerProfile(ctx, []common.ProfileType{common.ProfileTypeHeap}, 0, "")
+	assert.Equal(t, ErrorProfilerStopped, err)
+	assert.Equal(t, http.StatusServiceUnavailable, serve(http.MethodPost, "/profiler/trigger", "secret", `{"profile_types":["heap"]}`).Code)
+}
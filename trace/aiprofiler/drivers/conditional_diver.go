@@ -12,20 +12,32 @@ import (
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/settings_fetcher/settings_models"
 )
 
+// conditions can also reference keys of gauges registered by res_monitor.RegisterGauge
 const (
-	ConditionKeyCPURatio     = "cpu_ratio"
-	ConditionKeyMemRatio     = "mem_ratio"
-	ConditionKeyGoroutineNum = "goroutine_num"
+	ConditionKeyCPURatio     = res_monitor.KeyCPURatio
+	ConditionKeyMemRatio     = res_monitor.KeyMemRatio
+	ConditionKeyGoroutineNum = res_monitor.KeyGoroutineNum
 
-	ConditionCompareTypeThreshold = "threshold"
-	ConditionCompareTypeWindow    = "windows"
+	ConditionCompareTypeThreshold = "threshold" // compare current value
+	ConditionCompareTypeWindow    = "windows"   // compare change ratio with last window
+	ConditionCompareTypeRate      = "rate"      // compare change per second
 
-	ConditionOpGt = ">" //only support gt
+	ConditionOpGt = ">"
+	ConditionOpGe = ">="
+	ConditionOpLt = "<"
+	ConditionOpLe = "<="
 
 	executionLimitPerHour = 3 //as most 3 executions per hour for one profile
 	limitExpireTime       = time.Hour
 )
 
+var conditionOps = map[string]func(value, target float64) bool{
+	ConditionOpGt: func(value, target float64) bool { return value > target },
+	ConditionOpGe: func(value, target float64) bool { return value >= target },
+	ConditionOpLt: func(value, target float64) bool { return value < target },
+	ConditionOpLe: func(value, target float64) bool { return value <= target },
+}
+
 type count struct {
 	cnt      int32
 	expireAt time.Time
@@ -47,6 +59,7 @@ type ConditionalDriver struct {
 func NewConditionalDriver(taskChan chan *common.Task, monitor *res_monitor.Monitor, l logger.Logger) Driver {
 	if monitor == nil {
 		monitor = res_monitor.NewMonitor()
+		monitor.SetLogger(l)
 		monitor.Start()
 	}
 	return &ConditionalDriver{
@@ -75,6 +88,10 @@ func (d *ConditionalDriver) Handle(ps []*settings_models.Profile) {
 	d.profiles.Store(tmp) //those not represent in the latest settings will be considered as disabled.
 }
 
+func (d *ConditionalDriver) Profiles() []*settings_models.Profile {
+	return mapProfiles(&d.profiles)
+}
+
 func (d *ConditionalDriver) Start() {
 	tc := time.NewTicker(ConditionalCheckInterval)
 	d.wg.Add(1)
@@ -141,42 +158,53 @@ func (d *ConditionalDriver) Stop() {
 	d.wg.Wait()
 }
 
+// checkCondition returns true if any condition without and_group is met, or all conditions of any and_group are met.
+// For example, a OR (b AND c) is expressed by a with and_group 0, b and c with and_group 1
 func (d *ConditionalDriver) checkCondition(p *settings_models.Profile) bool {
-	result := false
+	groups := make(map[int32]bool) // and_group -> whether all conditions are met
 	for _, cond := range p.TriggerConditionList {
-		switch cond.Key {
-		case ConditionKeyCPURatio:
-			result = d.meetCondition(cond, d.resMonitor.GetCPURatio(), d.resMonitor.GetCPURatioDelta()) // value in cond is something like 1%
-			d.logger.Info("[ConditionalDriver.checkCondition] cond met? is %s. cond=%+v, cpuRatio=%+v, cpuDelta=%+v", result, cond, d.resMonitor.GetCPURatio(), d.resMonitor.GetCPURatioDelta())
-		case ConditionKeyMemRatio:
-			result = d.meetCondition(cond, d.resMonitor.GetMemRatio(), d.resMonitor.GetMemRatioDelta())
-			d.logger.Info("[ConditionalDriver.checkCondition] cond met? is %s. cond=%+v, memRatio=%+v, memDelta=%+v", result, cond, d.resMonitor.GetMemRatio(), d.resMonitor.GetMemRatioDelta())
-		case ConditionKeyGoroutineNum:
-			result = d.meetCondition(cond, d.resMonitor.GetGoRoutineNum(), d.resMonitor.GetGoRoutineNumDelta())
-			d.logger.Info("[ConditionalDriver.checkCondition] cond met? is %s. cond=%+v, goRoutine=%+v, goRoutineDelta=%+v", result, cond, d.resMonitor.GetGoRoutineNum(), d.resMonitor.GetGoRoutineNumDelta())
+		result := d.meetCondition(cond)
+		if cond.AndGroup == 0 {
+			if result {
+				return true // if any cond is met then we profile
+			}
+			continue
 		}
-		if result {
-			break // if any cond is met then we profile
+		allMet, ok := groups[cond.AndGroup]
+		groups[cond.AndGroup] = result && (allMet || !ok)
+	}
+	for _, allMet := range groups {
+		if allMet {
+			return true
 		}
 	}
-	return result
+	return false
 }
 
-func (d *ConditionalDriver) meetCondition(cond *settings_models.TriggerCondition, curValue, deltaValue float64) bool {
-	if cond.Compare == ConditionCompareTypeThreshold {
-		switch cond.Op {
-		case ConditionOpGt:
-			return curValue > toDecimal(cond.Value, cond.Unit)
-		}
-	} else if cond.Compare == ConditionCompareTypeWindow {
-		switch cond.Op {
-		case ConditionOpGt:
-			return deltaValue > toDecimal(cond.Value, cond.Unit)
-		}
-	} else {
+func (d *ConditionalDriver) meetCondition(cond *settings_models.TriggerCondition) bool {
+	values, ok := d.resMonitor.GetValues(cond.Key)
+	if !ok {
+		d.logger.Info("[ConditionalDriver.meetCondition] unknown key. cond=%+v", cond)
 		return false
 	}
-	return false
+	var value float64
+	switch cond.Compare {
+	case ConditionCompareTypeThreshold:
+		value = values.Current
+	case ConditionCompareTypeWindow:
+		value = values.Delta
+	case ConditionCompareTypeRate:
+		value = values.Rate
+	default:
+		return false
+	}
+	op, ok := conditionOps[cond.Op]
+	if !ok {
+		return false
+	}
+	result := op(value, toDecimal(cond.Value, cond.Unit)) // value in cond is something like 1%
+	d.logger.Info("[ConditionalDriver.meetCondition] cond met? is %t. cond=%+v, values=%+v", result, cond, values)
+	return result
 }
 
 func (d *ConditionalDriver) profileToTask(p *settings_models.Profile) *common.Task {
@@ -196,6 +224,7 @@ func (d *ConditionalDriver) profileToTask(p *settings_models.Profile) *common.Ta
 		UploadID:     utils.NewRandID(),
 		ProfileTypes: profileTypeList,
 		PtConfigs:    l,
+		DumpRecent:   true, // profiles recorded before conditions are met show how the anomaly begins
 	}
 }
 
@@ -0,0 +1,60 @@
+package drivers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/res_monitor"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/settings_fetcher/settings_models"
+)
+
+func TestCheckCondition(t *testing.T) {
+	assert.Nil(t, res_monitor.RegisterGauge("queue_depth", func() float64 { return 100 }))
+	assert.Nil(t, res_monitor.RegisterGauge("open_fds", func() float64 { return 50 }))
+	defer res_monitor.UnregisterGauge("queue_depth")
+	defer res_monitor.UnregisterGauge("open_fds")
+
+	monitor := res_monitor.NewMonitor()
+	monitor.Start() // values are sampled once started
+	monitor.Stop()
+	d := NewConditionalDriver(make(chan *common.Task, 1), monitor, &logger.NoopLogger{}).(*ConditionalDriver)
+
+	cond := func(key, op string, value float64, andGroup int32) *settings_models.TriggerCondition {
+		return &settings_models.TriggerCondition{Key: key, Compare: ConditionCompareTypeThreshold, Op: op, Value: value, AndGroup: andGroup}
+	}
+	cases := []struct {
+		conds []*settings_models.TriggerCondition
+		met   bool
+	}{
+		{[]*settings_models.TriggerCondition{cond("queue_depth", ConditionOpGt, 99, 0)}, true},
+		{[]*settings_models.TriggerCondition{cond("queue_depth", ConditionOpGe, 100, 0)}, true},
+		{[]*settings_models.TriggerCondition{cond("queue_depth", ConditionOpLt, 100, 0)}, false},
+		{[]*settings_models.TriggerCondition{cond("queue_depth", ConditionOpLe, 100, 0)}, true},
+		{[]*settings_models.TriggerCondition{cond("queue_depth", "!=", 0, 0)}, false},
+		{[]*settings_models.TriggerCondition{cond("unknown", ConditionOpGt, 0, 0)}, false},
+		{[]*settings_models.TriggerCondition{ // or
+			cond("queue_depth", ConditionOpGt, 200, 0),
+			cond("open_fds", ConditionOpGt, 10, 0),
+		}, true},
+		{[]*settings_models.TriggerCondition{ // and
+			cond("queue_depth", ConditionOpGt, 10, 1),
+			cond("open_fds", ConditionOpGt, 100, 1),
+		}, false},
+		{[]*settings_models.TriggerCondition{ // a or (b and c)
+			cond("queue_depth", ConditionOpGt, 200, 0),
+			cond("queue_depth", ConditionOpGt, 10, 1),
+			cond("open_fds", ConditionOpLt, 100, 1),
+		}, true},
+		{[]*settings_models.TriggerCondition{ // (a and b) or (c and d)
+			cond("queue_depth", ConditionOpGt, 200, 1),
+			cond("open_fds", ConditionOpGt, 10, 1),
+			cond("queue_depth", ConditionOpGt, 10, 2),
+			cond("open_fds", ConditionOpGt, 100, 2),
+		}, false},
+	}
+	for i, c := range cases {
+		assert.Equal(t, c.met, d.checkCondition(&settings_models.Profile{TriggerConditionList: c.conds}), "case %d", i)
+	}
+}
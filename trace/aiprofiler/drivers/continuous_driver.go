@@ -47,6 +47,10 @@ func (d *ContinuousDriver) Handle(ps []*settings_models.Profile) {
 	d.profiles.Store(tmp) // those not represent in the latest settings will be considered as disabled.
 }
 
+func (d *ContinuousDriver) Profiles() []*settings_models.Profile {
+	return mapProfiles(&d.profiles)
+}
+
 func (d *ContinuousDriver) Start() {
 	tc := time.NewTicker(ContinuousPeriodTime)
 	d.wg.Add(1)
@@ -82,17 +86,25 @@ func (d *ContinuousDriver) profileToTask(p *settings_models.Profile) *common.Tas
 	if !ok {
 		return nil
 	}
+	continuousTypes := make([]common.ProfileType, 0, len(profileTypeList))
 	l := make([]common.ProfileTypeConfig, 0, len(profileTypeList))
 	for _, pt := range profileTypeList {
+		if pt == common.ProfileTypeExecutionTrace { // execution trace is too heavy to be collected continuously
+			continue
+		}
+		continuousTypes = append(continuousTypes, pt)
 		if ptCfg, found := presetProfileTypeConfigs[pt]; found {
 			l = append(l, ptCfg)
 		}
 	}
+	if len(continuousTypes) == 0 {
+		return nil
+	}
 	return &common.Task{
 		Name:         "",
 		ProfileID:    p.ProfileId,
 		UploadID:     utils.NewRandID(),
-		ProfileTypes: profileTypeList,
+		ProfileTypes: continuousTypes,
 		PtConfigs:    l,
 		UseCache:     true, // for continuous, cache can be used to calculate delta
 	}
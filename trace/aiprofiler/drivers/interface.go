@@ -1,6 +1,8 @@
 package drivers
 
 import (
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
@@ -17,6 +19,8 @@ const (
 	Seconds60                = 60
 	ContinuousPeriodTime     = time.Second * Seconds60 // continuous period
 	ConditionalCheckInterval = time.Second * Seconds60 // time interval the conditions are checked
+
+	executionTraceSeconds = 5
 )
 
 // conditional and continuous profiles use preset settings, user can only decide profileType
@@ -42,11 +46,41 @@ var presetProfileTypeConfigs = map[common.ProfileType]common.ProfileTypeConfig{
 		ProfileType: common.ProfileTypeGoroutine,
 		IsSnapshot:  true,
 	},
+	common.ProfileTypeExecutionTrace: { // period. execution trace is heavy, keep it short
+		ProfileType:     common.ProfileTypeExecutionTrace,
+		DurationSeconds: executionTraceSeconds,
+	},
 }
 
 type Driver interface {
 	Name() string
 	Handle([]*settings_models.Profile)
+	Profiles() []*settings_models.Profile // active profiles
 	Start()
 	Stop()
 }
+
+// PresetProfileTypeConfig returns preset config of pt with durationSeconds, which is ignored for snapshot profile types
+func PresetProfileTypeConfig(pt common.ProfileType, durationSeconds int64) (common.ProfileTypeConfig, bool) {
+	ptCfg, ok := presetProfileTypeConfigs[pt]
+	if !ok {
+		return common.ProfileTypeConfig{}, false
+	}
+	if !ptCfg.IsSnapshot && durationSeconds > 0 {
+		ptCfg.DurationSeconds = durationSeconds
+	}
+	return ptCfg, true
+}
+
+// mapProfiles returns profiles stored in map
+func mapProfiles(v *atomic.Value) []*settings_models.Profile {
+	profiles, _ := v.Load().(map[int64]*settings_models.Profile)
+	res := make([]*settings_models.Profile, 0, len(profiles))
+	for _, p := range profiles {
+		res = append(res, p)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ProfileId < res[j].ProfileId
+	})
+	return res
+}
@@ -59,6 +59,11 @@ func (d *TimedDriver) Handle(ps []*settings_models.Profile) {
 	d.profiles.Store(tmp) //those not represent in the latest settings will be considered as disabled.
 }
 
+func (d *TimedDriver) Profiles() []*settings_models.Profile {
+	profiles, _ := d.profiles.Load().([]*settings_models.Profile)
+	return profiles
+}
+
 func (d *TimedDriver) Start() {
 	tc := time.NewTicker(TimedPeriodTime)
 	d.wg.Add(1)
@@ -0,0 +1,195 @@
+package aiprofiler
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+)
+
+const (
+	defaultFlightRecorderDuration = 5 * time.Minute
+	flightRecorderInterval        = time.Minute
+	flightRecorderCPUSeconds      = 10
+)
+
+// flightRecorderConfigs are configs of cheap profiles recorded in each interval
+var flightRecorderConfigs = []common.ProfileTypeConfig{
+	{ // period
+		ProfileType:     common.ProfileTypeCPU,
+		DurationSeconds: flightRecorderCPUSeconds,
+	},
+	{ // delta between intervals
+		ProfileType:            common.ProfileTypeHeap,
+		DurationSeconds:        int64(flightRecorderInterval / time.Second),
+		TargetDeltaSampleTypes: []common.SampleType{{Type: "alloc_objects", Unit: "count"}, {Type: "alloc_space", Unit: "bytes"}},
+	},
+	{ // snapshot
+		ProfileType: common.ProfileTypeGoroutine,
+		IsSnapshot:  true,
+	},
+}
+
+// recording is profiles recorded in one interval
+type recording struct {
+	startTimeMilliSec int64
+	endTimeMilliSec   int64
+	profiles          []*common.ProfileData
+}
+
+// flightRecorder keeps profiles of the most recent duration in memory, so that profiles before conditions are met
+// can be uploaded along with the conditional task. CPU profile of recorder is skipped if another CPU profile is running
+type flightRecorder struct {
+	lock       sync.Mutex
+	recordings []*recording // ring buffer
+	next       int
+
+	heapPrevious *profile.Profile // heap delta is calculated by recorder itself to keep cache of HeapProfileCollector
+
+	closeChan chan struct{}
+	wg        sync.WaitGroup
+
+	logger logger.Logger
+}
+
+func newFlightRecorder(duration time.Duration, l logger.Logger) *flightRecorder {
+	size := int(duration / flightRecorderInterval)
+	if size < 1 {
+		size = 1
+	}
+	return &flightRecorder{
+		recordings: make([]*recording, size),
+		closeChan:  make(chan struct{}),
+		logger:     l,
+	}
+}
+
+func (r *flightRecorder) Start() {
+	tc := time.NewTicker(flightRecorderInterval)
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer tc.Stop()
+		r.record() // heap profile of the first recording is a base of delta
+		for {
+			select {
+			case <-tc.C:
+				r.record()
+			case <-r.closeChan:
+				return
+			}
+		}
+	}()
+}
+
+func (r *flightRecorder) Stop() {
+	close(r.closeChan)
+	r.wg.Wait()
+}
+
+func (r *flightRecorder) record() {
+	rec := &recording{
+		startTimeMilliSec: time.Now().UnixNano() / 1e6,
+	}
+	if data, err := collectProfile(common.ProfileTypeGoroutine.ToString()); err == nil {
+		rec.profiles = append(rec.profiles, data)
+	} else {
+		r.logger.Error("[flightRecorder.record] collect goroutine fail. err=%+v", err)
+	}
+	if data, err := r.recordHeap(); err == nil {
+		if data != nil {
+			rec.profiles = append(rec.profiles, data)
+		}
+	} else {
+		r.logger.Error("[flightRecorder.record] collect heap fail. err=%+v", err)
+	}
+	if data, err := r.recordCPU(); err == nil {
+		if data != nil {
+			rec.profiles = append(rec.profiles, data)
+		}
+	} else {
+		r.logger.Error("[flightRecorder.record] collect cpu fail. err=%+v", err)
+	}
+	rec.endTimeMilliSec = time.Now().UnixNano() / 1e6
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.recordings[r.next] = rec
+	r.next = (r.next + 1) % len(r.recordings)
+}
+
+// recordHeap returns heap delta since last record, or nil for the first record
+func (r *flightRecorder) recordHeap() (*common.ProfileData, error) {
+	raw, err := collectProfile(common.ProfileTypeHeap.ToString())
+	if err != nil {
+		return nil, err
+	}
+	cur, err := profile.ParseData(raw.Data)
+	if err != nil {
+		return nil, err
+	}
+	pre := r.heapPrevious
+	r.heapPrevious = cur.Copy() // calculateDelta modifies pre
+	if pre == nil {
+		return nil, nil
+	}
+	delta, sampleMethod, err := calculateDelta(pre, cur, flightRecorderConfigs[1].TargetDeltaSampleTypes)
+	if err != nil {
+		return nil, err
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := delta.Write(buf); err != nil {
+		return nil, err
+	}
+	return &common.ProfileData{
+		Data:         buf.Bytes(),
+		ProfileType:  common.ProfileTypeHeap.ToString(),
+		SampleMethod: sampleMethod,
+	}, nil
+}
+
+// recordCPU returns CPU profile of a short window, or nil if another CPU profile is running
+func (r *flightRecorder) recordCPU() (*common.ProfileData, error) {
+	select {
+	case cpuProfiling <- struct{}{}:
+	default:
+		return nil, nil
+	}
+	defer func() {
+		<-cpuProfiling
+	}()
+	buf := bytes.NewBuffer(nil)
+	if err := pprof.StartCPUProfile(buf); err != nil {
+		return nil, err
+	}
+	select {
+	case <-time.After(flightRecorderCPUSeconds * time.Second):
+	case <-r.closeChan:
+	}
+	pprof.StopCPUProfile()
+	return &common.ProfileData{
+		Data:         buf.Bytes(),
+		SampleMethod: "period",
+		ProfileType:  common.ProfileTypeCPU.ToString(),
+	}, nil
+}
+
+// dump returns recordings from the oldest to the newest, and removes them from ring,
+// so that they are not uploaded again by conditional tasks triggered within duration
+func (r *flightRecorder) dump() []*recording {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	recordings := make([]*recording, 0, len(r.recordings))
+	for i := 0; i < len(r.recordings); i++ {
+		idx := (r.next + i) % len(r.recordings)
+		if rec := r.recordings[idx]; rec != nil && len(rec.profiles) != 0 {
+			recordings = append(recordings, rec)
+		}
+		r.recordings[idx] = nil
+	}
+	return recordings
+}
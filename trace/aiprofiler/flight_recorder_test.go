@@ -0,0 +1,38 @@
+package aiprofiler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	cpuProfiling <- struct{}{} // CPU profile of recorder is skipped
+	defer func() {
+		<-cpuProfiling
+	}()
+
+	r := newFlightRecorder(2*flightRecorderInterval, &logger.NoopLogger{})
+	assert.Empty(t, r.dump())
+
+	r.record()
+	recordings := r.dump()
+	assert.Len(t, recordings, 1)
+	assert.Len(t, recordings[0].profiles, 1) // heap profile of the first recording is a base of delta
+	assert.Equal(t, common.ProfileTypeGoroutine.ToString(), recordings[0].profiles[0].ProfileType)
+	assert.Empty(t, r.dump()) // dumped recordings are not dumped again
+
+	r.record()
+	r.record()
+	r.record()
+	recordings = r.dump()
+	assert.Len(t, recordings, 2)
+	for _, rec := range recordings {
+		assert.Len(t, rec.profiles, 2)
+		assert.Equal(t, common.ProfileTypeHeap.ToString(), rec.profiles[1].ProfileType)
+		assert.Equal(t, "mixed", rec.profiles[1].SampleMethod)
+	}
+	assert.True(t, recordings[0].startTimeMilliSec <= recordings[1].startTimeMilliSec)
+}
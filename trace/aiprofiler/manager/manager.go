@@ -117,6 +117,15 @@ func (m *Manager) HandlerProfileTasks(s *settings_models.Settings) {
 	}
 }
 
+// ActiveProfiles returns active profiles of each record type
+func (m *Manager) ActiveProfiles() map[string][]*settings_models.Profile {
+	return map[string][]*settings_models.Profile{
+		m.continuousDriver.Name():  m.continuousDriver.Profiles(),
+		m.timedDriver.Name():       m.timedDriver.Profiles(),
+		m.conditionalDriver.Name(): m.conditionalDriver.Profiles(),
+	}
+}
+
 func (m *Manager) Start() {
 	m.continuousDriver.Start()
 	m.timedDriver.Start()
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"errors"
 	"runtime/pprof"
+	"runtime/trace"
 	"time"
 
 	"github.com/google/pprof/profile"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
 )
 
 func GetProfileCollector(pt common.ProfileType) ProfileCollector {
@@ -23,6 +25,8 @@ var collectorRegister = map[common.ProfileType]ProfileCollector{
 	common.ProfileTypeBlock:     &BlockProfileCollector{},
 	common.ProfileTypeMutex:     &MutexProfileCollector{},
 	common.ProfileTypeGoroutine: &GoroutineProfileCollector{},
+
+	common.ProfileTypeExecutionTrace: &ExecutionTraceCollector{},
 }
 
 var ErrorUnknownProfile = errors.New("unknown profile")
@@ -57,7 +61,14 @@ func (p *CPUProfileCollector) GetPrevious() *profile.Profile {
 func (p *CPUProfileCollector) SetPrevious(*profile.Profile) {
 }
 
+// cpuProfiling is held while CPU profile is running, since only one CPU profile can be running at the same time
+var cpuProfiling = make(chan struct{}, 1)
+
 func (p *CPUProfileCollector) Collect(durationSeconds int64, _, _ bool, _ []common.SampleType) (*common.ProfileData, error) { // CPU profile does not support snapshot or delta
+	cpuProfiling <- struct{}{} // wait for CPU profile of flight recorder
+	defer func() {
+		<-cpuProfiling
+	}()
 	buf := bytes.NewBuffer(nil)
 	if err := pprof.StartCPUProfile(buf); err != nil {
 		return nil, err
@@ -199,6 +210,104 @@ func (p *GoroutineProfileCollector) Collect(durationSeconds int64, isSnapshot, u
 	return collectProfile(p.Name())
 }
 
+const (
+	defaultExecutionTraceDuration = 5 * time.Second
+	maxExecutionTraceDuration     = 60 * time.Second
+	maxExecutionTraceBytes        = 32 << 20
+)
+
+// ExecutionTraceCollector collects execution trace by runtime/trace, which shows scheduling, syscall and GC pause events that pprof does not.
+// Execution trace has considerable overhead, so duration is bounded by maxExecutionTraceDuration, and tracing stops early
+// once maxExecutionTraceBytes is written. Only one execution trace can be collected at the same time
+type ExecutionTraceCollector struct{}
+
+func (p *ExecutionTraceCollector) Name() string {
+	return common.ProfileTypeExecutionTrace.ToString()
+}
+
+func (p *ExecutionTraceCollector) FileName() string {
+	return common.ProfileTypeExecutionTrace.ToString() + ".out"
+}
+
+func (p *ExecutionTraceCollector) SupportDelta() bool {
+	return false
+}
+
+func (p *ExecutionTraceCollector) GetPrevious() *profile.Profile {
+	return nil
+}
+
+func (p *ExecutionTraceCollector) SetPrevious(*profile.Profile) {
+}
+
+func (p *ExecutionTraceCollector) Collect(durationSeconds int64, _, _ bool, _ []common.SampleType) (*common.ProfileData, error) { // execution trace does not support snapshot or delta
+	d := time.Duration(durationSeconds) * time.Second
+	if d <= 0 {
+		d = defaultExecutionTraceDuration
+	}
+	if d > maxExecutionTraceDuration {
+		d = maxExecutionTraceDuration
+	}
+	buf := newLimitedBuffer(maxExecutionTraceBytes)
+	if err := trace.Start(buf); err != nil { // fails if execution trace is already enabled
+		return nil, err
+	}
+	select {
+	case <-time.After(d):
+	case <-buf.full:
+	}
+	trace.Stop() // returns after all trace data is written
+	return &common.ProfileData{
+		Data:         buf.Bytes(),
+		SampleMethod: "period",
+		ProfileType:  p.Name(),
+	}, nil
+}
+
+// limitedBuffer notifies full when limit bytes are written. Writes are never truncated,
+// since a truncated execution trace can not be parsed, so data may exceed limit by what is flushed after stop
+type limitedBuffer struct {
+	bytes.Buffer
+	limit  int
+	full   chan struct{}
+	closed bool
+}
+
+func newLimitedBuffer(limit int) *limitedBuffer {
+	return &limitedBuffer{
+		limit: limit,
+		full:  make(chan struct{}),
+	}
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n, err := b.Buffer.Write(p)
+	if b.Len() >= b.limit && !b.closed {
+		b.closed = true
+		close(b.full)
+	}
+	return n, err
+}
+
+// hasPprofLabels returns true if any cpu or goroutine profile has samples labeled by tracer
+func hasPprofLabels(batchProfileData []*common.ProfileData) bool {
+	for _, d := range batchProfileData {
+		if d == nil || (d.ProfileType != common.ProfileTypeCPU.ToString() && d.ProfileType != common.ProfileTypeGoroutine.ToString()) {
+			continue
+		}
+		p, err := profile.ParseData(d.Data)
+		if err != nil {
+			continue
+		}
+		for _, sample := range p.Sample {
+			if len(sample.Label[internal.PprofLabelTraceID]) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func sleep(d time.Duration) {
 	select {
 	case <-time.After(d):
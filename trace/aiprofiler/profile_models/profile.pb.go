@@ -33,7 +33,7 @@ func (m *ProfileInfo) Reset()         { *m = ProfileInfo{} }
 func (m *ProfileInfo) String() string { return proto.CompactTextString(m) }
 func (*ProfileInfo) ProtoMessage()    {}
 func (*ProfileInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_profile_bd3c6b70221d1fe2, []int{0}
+	return fileDescriptor_profile_0f37292deee0ffc3, []int{0}
 }
 func (m *ProfileInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -93,7 +93,7 @@ func (m *DataWrapper) Reset()         { *m = DataWrapper{} }
 func (m *DataWrapper) String() string { return proto.CompactTextString(m) }
 func (*DataWrapper) ProtoMessage()    {}
 func (*DataWrapper) Descriptor() ([]byte, []int) {
-	return fileDescriptor_profile_bd3c6b70221d1fe2, []int{1}
+	return fileDescriptor_profile_0f37292deee0ffc3, []int{1}
 }
 func (m *DataWrapper) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -162,13 +162,16 @@ type UploadInfo struct {
 	CpuLimit             int64   `protobuf:"varint,35,opt,name=cpu_limit,json=cpuLimit" json:"cpu_limit"`
 	ProcessCpuUsageRatio float64 `protobuf:"fixed64,50,opt,name=process_cpu_usage_ratio,json=processCpuUsageRatio" json:"process_cpu_usage_ratio"`
 	ProcessMemRssRatio   float64 `protobuf:"fixed64,51,opt,name=process_mem_rss_ratio,json=processMemRssRatio" json:"process_mem_rss_ratio"`
+	HasPprofLabels       bool    `protobuf:"varint,36,opt,name=has_pprof_labels,json=hasPprofLabels" json:"has_pprof_labels"`
+	TriggerUploadId      string  `protobuf:"bytes,37,opt,name=trigger_upload_id,json=triggerUploadId" json:"trigger_upload_id"`
+	TriggerReason        string  `protobuf:"bytes,38,opt,name=trigger_reason,json=triggerReason" json:"trigger_reason"`
 }
 
 func (m *UploadInfo) Reset()         { *m = UploadInfo{} }
 func (m *UploadInfo) String() string { return proto.CompactTextString(m) }
 func (*UploadInfo) ProtoMessage()    {}
 func (*UploadInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_profile_bd3c6b70221d1fe2, []int{2}
+	return fileDescriptor_profile_0f37292deee0ffc3, []int{2}
 }
 func (m *UploadInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -323,6 +326,27 @@ func (m *UploadInfo) GetProcessMemRssRatio() float64 {
 	return 0
 }
 
+func (m *UploadInfo) GetHasPprofLabels() bool {
+	if m != nil {
+		return m.HasPprofLabels
+	}
+	return false
+}
+
+func (m *UploadInfo) GetTriggerUploadId() string {
+	if m != nil {
+		return m.TriggerUploadId
+	}
+	return ""
+}
+
+func (m *UploadInfo) GetTriggerReason() string {
+	if m != nil {
+		return m.TriggerReason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ProfileInfo)(nil), "profile_models.ProfileInfo")
 	proto.RegisterType((*DataWrapper)(nil), "profile_models.DataWrapper")
@@ -486,6 +510,28 @@ func (m *UploadInfo) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x2
 	i++
 	i = encodeVarintProfile(dAtA, i, uint64(m.CpuLimit))
+	dAtA[i] = 0xa0
+	i++
+	dAtA[i] = 0x2
+	i++
+	if m.HasPprofLabels {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
+	dAtA[i] = 0xaa
+	i++
+	dAtA[i] = 0x2
+	i++
+	i = encodeVarintProfile(dAtA, i, uint64(len(m.TriggerUploadId)))
+	i += copy(dAtA[i:], m.TriggerUploadId)
+	dAtA[i] = 0xb2
+	i++
+	dAtA[i] = 0x2
+	i++
+	i = encodeVarintProfile(dAtA, i, uint64(len(m.TriggerReason)))
+	i += copy(dAtA[i:], m.TriggerReason)
 	dAtA[i] = 0x91
 	i++
 	dAtA[i] = 0x3
@@ -583,6 +629,11 @@ func (m *UploadInfo) Size() (n int) {
 	l = len(m.GoRuntimeInfo)
 	n += 2 + l + sovProfile(uint64(l))
 	n += 2 + sovProfile(uint64(m.CpuLimit))
+	n += 3
+	l = len(m.TriggerUploadId)
+	n += 2 + l + sovProfile(uint64(l))
+	l = len(m.TriggerReason)
+	n += 2 + l + sovProfile(uint64(l))
 	n += 10
 	n += 10
 	return n
@@ -1356,6 +1407,84 @@ func (m *UploadInfo) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 36:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasPprofLabels", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasPprofLabels = bool(v != 0)
+		case 37:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TriggerUploadId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProfile
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TriggerUploadId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 38:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TriggerReason", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProfile
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TriggerReason = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		case 50:
 			if wireType != 1 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ProcessCpuUsageRatio", wireType)
@@ -1525,45 +1654,49 @@ var (
 	ErrIntOverflowProfile   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("profile.proto", fileDescriptor_profile_bd3c6b70221d1fe2) }
+func init() { proto.RegisterFile("profile.proto", fileDescriptor_profile_0f37292deee0ffc3) }
 
-var fileDescriptor_profile_bd3c6b70221d1fe2 = []byte{
-	// 584 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x93, 0x3d, 0x6f, 0x13, 0x31,
-	0x1c, 0xc6, 0x73, 0xe9, 0x5b, 0xf2, 0xbf, 0x4b, 0x91, 0x2c, 0x10, 0x27, 0x5e, 0xd2, 0x6b, 0x0a,
-	0x6a, 0x40, 0x28, 0x43, 0x19, 0x18, 0x2a, 0x31, 0xb4, 0x2c, 0x91, 0x5a, 0x84, 0xa2, 0x56, 0x8c,
-	0x96, 0x39, 0x3b, 0xa9, 0xa5, 0xf8, 0x6c, 0xd9, 0x3e, 0xa4, 0x8a, 0x91, 0x2f, 0xc0, 0xc7, 0xea,
-	0xd8, 0x81, 0x81, 0x09, 0xa1, 0xf6, 0x8b, 0x20, 0xfb, 0x7c, 0xa9, 0x0b, 0x53, 0x2e, 0xcf, 0xf3,
-	0x7b, 0xfc, 0x7f, 0x91, 0x0d, 0x03, 0xa5, 0xe5, 0x9c, 0x2f, 0xd9, 0x44, 0x69, 0x69, 0x25, 0xda,
-	0x0e, 0x7f, 0xb1, 0x90, 0x94, 0x2d, 0xcd, 0xe8, 0x7b, 0x02, 0xe9, 0xa7, 0x46, 0x9a, 0x56, 0x73,
-	0x89, 0x0e, 0x21, 0xad, 0xd5, 0x52, 0x12, 0x8a, 0x79, 0x35, 0x97, 0x79, 0x52, 0x74, 0xc7, 0xe9,
-	0xc1, 0x93, 0xc9, 0xfd, 0xd4, 0xe4, 0xdc, 0x23, 0x2e, 0x30, 0x83, 0x7a, 0xf5, 0x8d, 0x72, 0x58,
-	0xa7, 0xc4, 0x92, 0x9c, 0x16, 0xdd, 0x71, 0x76, 0xb4, 0x7e, 0xf5, 0x7b, 0xa7, 0x33, 0xf3, 0x0a,
-	0x7a, 0x0e, 0x20, 0xea, 0xa5, 0xe5, 0xd8, 0xfb, 0xac, 0x58, 0x1b, 0x67, 0xb3, 0xbe, 0x57, 0x3e,
-	0x10, 0x4b, 0x46, 0xdf, 0x20, 0x75, 0xbf, 0x9f, 0x35, 0x51, 0x8a, 0xe9, 0xd5, 0x39, 0xc9, 0x7f,
-	0xe7, 0xec, 0x43, 0xd6, 0xb6, 0x62, 0x2f, 0x15, 0xcb, 0xbb, 0x45, 0x77, 0xdc, 0x0f, 0x44, 0x1a,
-	0x9c, 0xb3, 0x4b, 0xc5, 0xd0, 0x2b, 0x18, 0x18, 0x22, 0x94, 0x6b, 0x99, 0xd9, 0x0b, 0x49, 0xf3,
-	0xb5, 0x88, 0xcc, 0x1a, 0xeb, 0xd4, 0x3b, 0xa3, 0x9f, 0x1b, 0x00, 0x77, 0x03, 0xa1, 0xa7, 0xb0,
-	0x49, 0x94, 0xc2, 0x9c, 0xfa, 0xf2, 0x6d, 0x64, 0x83, 0x28, 0x35, 0xa5, 0x68, 0x0f, 0xa0, 0xad,
-	0xcf, 0xa9, 0xaf, 0xbe, 0x16, 0x80, 0x7e, 0xd0, 0xa7, 0x14, 0xed, 0x42, 0xbf, 0xdd, 0xe1, 0xfd,
-	0xba, 0xbd, 0xb0, 0x2b, 0x8a, 0x0a, 0xe8, 0x5d, 0x48, 0x63, 0x2b, 0x22, 0x58, 0xbe, 0x5e, 0x24,
-	0x77, 0x44, 0xab, 0xba, 0x49, 0x4b, 0x59, 0x59, 0xc2, 0x2b, 0xa6, 0xdd, 0x39, 0x1b, 0x11, 0x95,
-	0xae, 0x9c, 0x29, 0x45, 0x2f, 0x21, 0xe5, 0x95, 0xb1, 0xa4, 0x2a, 0x7d, 0x4f, 0x9b, 0x11, 0x07,
-	0xad, 0xd1, 0x60, 0x9a, 0x95, 0x52, 0x53, 0xec, 0x8b, 0x6e, 0xc5, 0x58, 0x63, 0x7c, 0x0c, 0x65,
-	0x75, 0x5d, 0x59, 0x2e, 0xc2, 0x82, 0x7b, 0x71, 0xd9, 0xe0, 0xf8, 0x05, 0x3f, 0x83, 0xcd, 0xb9,
-	0xd4, 0x82, 0xd8, 0xbc, 0x1f, 0x21, 0x41, 0x73, 0x7b, 0x32, 0x96, 0x68, 0x8b, 0x1d, 0x9f, 0x43,
-	0xbc, 0x27, 0xaf, 0x9f, 0x71, 0xc1, 0xd0, 0x0e, 0xf4, 0x58, 0x45, 0x1b, 0x24, 0x8d, 0x90, 0x2d,
-	0x56, 0xd1, 0x16, 0x20, 0x8a, 0x37, 0x40, 0x16, 0x03, 0x44, 0x71, 0x0f, 0xec, 0x43, 0x66, 0x98,
-	0xfe, 0xca, 0x4b, 0xd6, 0x4c, 0x35, 0x88, 0xaf, 0x43, 0x70, 0xfc, 0x58, 0xaf, 0x61, 0x5b, 0x59,
-	0x5c, 0xca, 0x6a, 0xce, 0x17, 0x78, 0xc9, 0x8d, 0xcd, 0xb7, 0xa3, 0xae, 0x33, 0x65, 0x8f, 0xbd,
-	0x75, 0xc2, 0x8d, 0x45, 0x6f, 0xe0, 0xc1, 0x42, 0xe2, 0x76, 0x0b, 0xfe, 0x19, 0x8c, 0x22, 0x78,
-	0xb0, 0x90, 0xb3, 0xc6, 0xf3, 0xd7, 0x65, 0x17, 0xfa, 0xa5, 0xaa, 0xf1, 0x92, 0x0b, 0x6e, 0xf3,
-	0xbd, 0x22, 0x59, 0x35, 0xd9, 0x2b, 0x55, 0x7d, 0xe2, 0x54, 0x74, 0x08, 0x8f, 0x95, 0x96, 0x25,
-	0x33, 0x06, 0x3b, 0xb4, 0x36, 0x64, 0xc1, 0xb0, 0x26, 0x96, 0xcb, 0xfc, 0xa0, 0x48, 0xc6, 0x49,
-	0x08, 0x3c, 0x0c, 0xd0, 0xb1, 0xaa, 0xcf, 0x1d, 0x32, 0x73, 0x04, 0x7a, 0x07, 0x8f, 0xda, 0xb0,
-	0x60, 0x02, 0x6b, 0x63, 0x42, 0xf4, 0x6d, 0x14, 0x45, 0x01, 0x39, 0x65, 0x62, 0x66, 0x8c, 0x0f,
-	0x1e, 0xbd, 0xbf, 0xba, 0x19, 0x26, 0xd7, 0x37, 0xc3, 0xe4, 0xcf, 0xcd, 0x30, 0xf9, 0x71, 0x3b,
-	0xec, 0x5c, 0xdf, 0x0e, 0x3b, 0xbf, 0x6e, 0x87, 0x1d, 0x78, 0x51, 0x4a, 0x31, 0xf9, 0x72, 0x69,
-	0x19, 0x75, 0x77, 0xc3, 0x7f, 0x11, 0x25, 0xfe, 0x79, 0xe3, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff,
-	0x27, 0x90, 0x8a, 0x61, 0x39, 0x04, 0x00, 0x00,
+var fileDescriptor_profile_0f37292deee0ffc3 = []byte{
+	// 648 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x94, 0xcd, 0x4e, 0x1b, 0x3f,
+	0x14, 0xc5, 0x33, 0x01, 0x42, 0x72, 0x27, 0x09, 0xff, 0xbf, 0xd5, 0xaa, 0xa3, 0x7e, 0x84, 0x21,
+	0x40, 0x49, 0x3f, 0x14, 0x55, 0x74, 0xd1, 0x05, 0x52, 0x17, 0xd0, 0x4d, 0x24, 0xa8, 0x50, 0x04,
+	0xea, 0xd2, 0x32, 0x63, 0x27, 0xb1, 0x34, 0x33, 0xb6, 0x6c, 0x4f, 0x25, 0xd4, 0x65, 0x5f, 0xa0,
+	0x8f, 0xc5, 0x92, 0x65, 0x57, 0x55, 0x45, 0x5e, 0xa4, 0x1a, 0x8f, 0x27, 0x98, 0x76, 0x15, 0xe7,
+	0x9e, 0xdf, 0xf1, 0x3d, 0xbe, 0xba, 0x09, 0xf4, 0xa4, 0x12, 0x33, 0x9e, 0xb2, 0xb1, 0x54, 0xc2,
+	0x08, 0xd4, 0x77, 0x5f, 0x71, 0x26, 0x28, 0x4b, 0xf5, 0xf0, 0x7b, 0x00, 0xe1, 0x79, 0x55, 0x9a,
+	0xe4, 0x33, 0x81, 0x8e, 0x20, 0x2c, 0x64, 0x2a, 0x08, 0xc5, 0x3c, 0x9f, 0x89, 0x28, 0x88, 0x9b,
+	0xa3, 0xf0, 0xf0, 0xe9, 0xf8, 0xa1, 0x6b, 0x7c, 0x69, 0x91, 0xd2, 0x30, 0x85, 0x62, 0x75, 0x46,
+	0x11, 0xac, 0x53, 0x62, 0x48, 0x44, 0xe3, 0xe6, 0xa8, 0x7b, 0xbc, 0x7e, 0xf3, 0x6b, 0xbb, 0x31,
+	0xb5, 0x15, 0xf4, 0x02, 0x20, 0x2b, 0x52, 0xc3, 0xb1, 0xd5, 0x59, 0xbc, 0x36, 0xea, 0x4e, 0x3b,
+	0xb6, 0xf2, 0x89, 0x18, 0x32, 0xfc, 0x06, 0x61, 0xf9, 0xf9, 0x45, 0x11, 0x29, 0x99, 0x5a, 0xdd,
+	0x13, 0xfc, 0x73, 0xcf, 0x01, 0x74, 0xeb, 0x28, 0xe6, 0x5a, 0xb2, 0xa8, 0x19, 0x37, 0x47, 0x1d,
+	0x47, 0x84, 0x4e, 0xb9, 0xb8, 0x96, 0x0c, 0xbd, 0x82, 0x9e, 0x26, 0x99, 0x2c, 0x23, 0x33, 0xb3,
+	0x10, 0x34, 0x5a, 0xf3, 0xc8, 0x6e, 0x25, 0x9d, 0x59, 0x65, 0xb8, 0x6c, 0x01, 0xdc, 0x3f, 0x08,
+	0x3d, 0x83, 0x16, 0x91, 0x12, 0x73, 0x1a, 0x05, 0x9e, 0x65, 0x83, 0x48, 0x39, 0xa1, 0x68, 0x17,
+	0xa0, 0xee, 0xcf, 0xa9, 0xed, 0xbe, 0xe6, 0x80, 0x8e, 0xab, 0x4f, 0x28, 0xda, 0x81, 0x4e, 0x3d,
+	0xc3, 0x87, 0x7d, 0xdb, 0x6e, 0x56, 0x14, 0xc5, 0xd0, 0x5e, 0x08, 0x6d, 0x72, 0x92, 0xb1, 0x68,
+	0x3d, 0x0e, 0xee, 0x89, 0xba, 0x5a, 0xbe, 0x34, 0x11, 0xb9, 0x21, 0x3c, 0x67, 0xaa, 0xbc, 0x67,
+	0xc3, 0xa3, 0xc2, 0x95, 0x32, 0xa1, 0x68, 0x1f, 0x42, 0x9e, 0x6b, 0x43, 0xf2, 0xc4, 0x66, 0x6a,
+	0x79, 0x1c, 0xd4, 0x42, 0x85, 0x29, 0x96, 0x08, 0x45, 0xb1, 0x6d, 0xba, 0xe9, 0x63, 0x95, 0xf0,
+	0xd9, 0xb5, 0x55, 0x45, 0x6e, 0x78, 0xe6, 0x06, 0xdc, 0xf6, 0xdb, 0x3a, 0xc5, 0x0e, 0xf8, 0x39,
+	0xb4, 0x66, 0x42, 0x65, 0xc4, 0x44, 0x1d, 0x0f, 0x71, 0xb5, 0x72, 0x4e, 0xda, 0x10, 0x65, 0x70,
+	0xc9, 0x47, 0xe0, 0xcf, 0xc9, 0xd6, 0x2f, 0x78, 0xc6, 0xd0, 0x36, 0xb4, 0x59, 0x4e, 0x2b, 0x24,
+	0xf4, 0x90, 0x4d, 0x96, 0xd3, 0x1a, 0x20, 0x92, 0x57, 0x40, 0xd7, 0x07, 0x88, 0xe4, 0x16, 0x38,
+	0x80, 0xae, 0x66, 0xea, 0x2b, 0x4f, 0x58, 0xf5, 0xaa, 0x9e, 0xbf, 0x0e, 0x4e, 0xb1, 0xcf, 0x7a,
+	0x0d, 0x7d, 0x69, 0x70, 0x22, 0xf2, 0x19, 0x9f, 0xe3, 0x94, 0x6b, 0x13, 0xf5, 0xbd, 0xd4, 0x5d,
+	0x69, 0x4e, 0xac, 0x74, 0xca, 0xb5, 0x41, 0x6f, 0x61, 0x6b, 0x2e, 0x70, 0x3d, 0x05, 0xfb, 0x33,
+	0x18, 0x7a, 0x70, 0x6f, 0x2e, 0xa6, 0x95, 0x66, 0xd7, 0x65, 0x07, 0x3a, 0x89, 0x2c, 0x70, 0xca,
+	0x33, 0x6e, 0xa2, 0xdd, 0x38, 0x58, 0x85, 0x6c, 0x27, 0xb2, 0x38, 0x2d, 0xab, 0x68, 0x0c, 0xff,
+	0x2d, 0x88, 0xc6, 0xb2, 0xdc, 0x10, 0x9c, 0x92, 0x2b, 0x96, 0xea, 0x68, 0x2f, 0x0e, 0x46, 0x6d,
+	0x47, 0xf6, 0x17, 0x44, 0x9f, 0x97, 0xe2, 0xa9, 0xd5, 0xd0, 0x3b, 0xf8, 0xdf, 0x28, 0x3e, 0x9f,
+	0x33, 0x85, 0xef, 0xf7, 0x68, 0xdf, 0x8b, 0xb0, 0xe5, 0xe4, 0xcb, 0x7a, 0x9d, 0xde, 0x40, 0xbf,
+	0x76, 0x28, 0x46, 0xb4, 0xc8, 0xa3, 0x97, 0x7e, 0x62, 0xa7, 0x4d, 0xad, 0x84, 0x8e, 0xe0, 0x89,
+	0x54, 0x22, 0x61, 0x5a, 0xe3, 0x32, 0x79, 0xa1, 0xc9, 0x9c, 0x61, 0x45, 0x0c, 0x17, 0xd1, 0x61,
+	0x1c, 0x8c, 0x02, 0xe7, 0x7a, 0xe4, 0xa0, 0x13, 0x59, 0x5c, 0x96, 0xc8, 0xb4, 0x24, 0xd0, 0x07,
+	0x78, 0x5c, 0x9b, 0x33, 0x96, 0x61, 0xa5, 0xb5, 0xb3, 0xbe, 0xf7, 0xac, 0xc8, 0x21, 0x67, 0x2c,
+	0x9b, 0x6a, 0x6d, 0x8d, 0xc7, 0x1f, 0x6f, 0xee, 0x06, 0xc1, 0xed, 0xdd, 0x20, 0xf8, 0x7d, 0x37,
+	0x08, 0x7e, 0x2c, 0x07, 0x8d, 0xdb, 0xe5, 0xa0, 0xf1, 0x73, 0x39, 0x68, 0xc0, 0x5e, 0x22, 0xb2,
+	0xf1, 0xd5, 0xb5, 0x61, 0xb4, 0x5c, 0x55, 0x7b, 0x22, 0x32, 0xfb, 0xeb, 0x2f, 0xe7, 0xcf, 0x00,
+	0xf5, 0x14, 0x8e, 0x29, 0xc8, 0x04, 0x00, 0x00,
 }
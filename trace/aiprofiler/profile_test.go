@@ -0,0 +1,55 @@
+package aiprofiler
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
+)
+
+func TestHasPprofLabels(t *testing.T) {
+	collector := &GoroutineProfileCollector{}
+
+	data, err := collector.Collect(0, true, false, nil)
+	assert.Nil(t, err)
+	assert.False(t, hasPprofLabels([]*common.ProfileData{data}))
+
+	blocked, done := make(chan struct{}), make(chan struct{})
+	defer close(done)
+	go pprof.Do(context.Background(), pprof.Labels(internal.PprofLabelTraceID, "trace"), func(context.Context) {
+		close(blocked)
+		<-done
+	})
+	<-blocked
+	data, err = collector.Collect(0, true, false, nil)
+	assert.Nil(t, err)
+	assert.True(t, hasPprofLabels([]*common.ProfileData{nil, data}))
+}
+
+func TestExecutionTraceCollector(t *testing.T) {
+	pt, ok := common.FromString("trace")
+	assert.True(t, ok)
+	collector := GetProfileCollector(pt)
+	assert.NotNil(t, collector)
+
+	data, err := collector.Collect(1, false, false, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "trace", data.ProfileType)
+	assert.True(t, bytes.HasPrefix(data.Data, []byte("go 1.")))
+
+	buf := newLimitedBuffer(4)
+	_, _ = buf.Write([]byte("go"))
+	select {
+	case <-buf.full:
+		t.Fatal("should not be full")
+	default:
+	}
+	_, _ = buf.Write([]byte(" 1.16"))
+	_, _ = buf.Write([]byte(" trace"))
+	<-buf.full
+	assert.Equal(t, "go 1.16 trace", buf.String())
+}
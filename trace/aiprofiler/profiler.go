@@ -1,17 +1,22 @@
 package aiprofiler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"runtime"
 	"sync"
 	"time"
 
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/common"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/drivers"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/manager"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/p_runtime"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/profile_models"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/res_monitor"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/sender"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aiprofiler/utils"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/agentless_adapter"
@@ -28,9 +33,20 @@ const (
 
 	defaultBackoffInterval = time.Minute
 
+	defaultTriggerDuration = time.Minute
+	maxTriggerDuration     = 10 * time.Minute
+	minTriggerInterval     = 10 * time.Second
+
 	defaultSockAddr = "/var/run/apminsight/comm.sock"
 )
 
+var (
+	ErrorNoProfileType      = errors.New("no profile type")
+	ErrorProfilerStopped    = errors.New("profiler stopped")
+	ErrorProfilerBusy       = errors.New("profiler busy")
+	ErrorTriggerTooFrequent = errors.New("trigger too frequent")
+)
+
 type Config struct {
 	TaskChanSize int
 	OutChanSize  int
@@ -45,6 +61,9 @@ type Config struct {
 	mutexFraction int
 	blockRate     int
 
+	// flight recorder
+	flightRecorderDuration time.Duration
+
 	Logger logger.Logger
 }
 
@@ -63,6 +82,14 @@ type Profiler struct {
 
 	sender sender.Sender
 
+	recorder *flightRecorder
+
+	lock    sync.RWMutex // protects taskChan from being written after closed
+	stopped bool
+
+	triggerLock     sync.Mutex
+	lastTriggerTime time.Time // time of the last task enqueued by TriggerProfile
+
 	wg sync.WaitGroup
 
 	logger logger.Logger
@@ -176,6 +203,32 @@ func WithMutexProfileDefault() Option {
 	}
 }
 
+// WithFlightRecorder enables flight recorder, which keeps cheap profiles (goroutine snapshot, heap delta and 10s CPU profile per minute)
+// of the most recent duration in memory. When conditions of a conditional profile are met, recorded profiles are uploaded before
+// the new profile, linked by trigger_upload_id, so that what happens before the anomaly is visible.
+// Flight recorder is disabled by default.
+func WithFlightRecorder(duration time.Duration) Option {
+	return func(config *Config) {
+		config.flightRecorderDuration = duration
+	}
+}
+
+func WithFlightRecorderDefault() Option {
+	return func(config *Config) {
+		config.flightRecorderDuration = defaultFlightRecorderDuration
+	}
+}
+
+// RegisterConditionGauge registers a custom gauge with key, like queue depth, p99 latency, gc pause or open fds.
+// Trigger conditions of conditional profiles can reference the key besides cpu_ratio, mem_ratio and goroutine_num
+func RegisterConditionGauge(key string, g res_monitor.Gauge) error {
+	return res_monitor.RegisterGauge(key, g)
+}
+
+func UnregisterConditionGauge(key string) {
+	res_monitor.UnregisterGauge(key)
+}
+
 // NewProfiler fetch profileTasks from remoteConfig then profile and send pprof data to backend
 func NewProfiler(serviceType, service string, opts ...Option) *Profiler {
 	cfg := newDefaultConfig()
@@ -207,6 +260,7 @@ func NewProfiler(serviceType, service string, opts ...Option) *Profiler {
 	}
 
 	resMonitor := res_monitor.NewMonitor()
+	resMonitor.SetLogger(cfg.Logger)
 	p.resMonitor = resMonitor
 
 	// register is singleton, so is safe to call it multiple time
@@ -214,6 +268,9 @@ func NewProfiler(serviceType, service string, opts ...Option) *Profiler {
 
 	p.manager = manager.NewManager(service, cfg.SettingsCfg, taskChan, resMonitor, cfg.Logger)
 	p.sender = sender.NewSender(cfg.SenderCfg, outChan)
+	if cfg.flightRecorderDuration > 0 {
+		p.recorder = newFlightRecorder(cfg.flightRecorderDuration, cfg.Logger)
+	}
 
 	cfg.Logger.Info("[NewProfiler] init profiler success. config is %+v", cfg)
 
@@ -227,6 +284,9 @@ func (p *Profiler) Start() {
 	p.resMonitor.Start()
 	p.manager.Start()
 	p.sender.Start()
+	if p.recorder != nil {
+		p.recorder.Start()
+	}
 	p.wg.Add(1)
 	go func() {
 		defer p.wg.Done()
@@ -241,15 +301,25 @@ func (p *Profiler) Stop() {
 	p.logger.Info("Stopping profiler...")
 
 	p.manager.Stop()         // close manager first to avoid write to a closed chan
-	close(p.taskChan)        // no task will be executed
+	p.closeTaskChan()        // no task will be executed
 	p.wg.Wait()              // wait runLoop/run to stop, which means no data will be sent
 	p.resMonitor.Stop()      // res monitor is needed in task-run
 	p.sender.Stop()          // close sender
 	p.serviceRegister.Stop() // close register
+	if p.recorder != nil {
+		p.recorder.Stop() // recorded profiles are sent by run only
+	}
 
 	p.logger.Info("profiler stopped")
 }
 
+func (p *Profiler) closeTaskChan() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.stopped = true
+	close(p.taskChan)
+}
+
 // DebugTasks create a debug task.
 // DEBUG ONLY.
 func (p *Profiler) DebugTasks(tasks []*common.Task) {
@@ -258,6 +328,58 @@ func (p *Profiler) DebugTasks(tasks []*common.Task) {
 	}
 }
 
+// TriggerProfile enqueues a task which profiles types for duration, and returns UploadID of the task.
+// duration is ignored by snapshot profile types, defaults to 1 minute, and is limited to 10 minutes.
+// reason is uploaded along with profiles. It does not block, ErrorProfilerBusy is returned if task queue is full,
+// and ErrorTriggerTooFrequent is returned if called again within 10 seconds since the last task is enqueued
+func (p *Profiler) TriggerProfile(ctx context.Context, types []common.ProfileType, duration time.Duration, reason string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if len(types) == 0 {
+		return "", ErrorNoProfileType
+	}
+	if duration <= 0 {
+		duration = defaultTriggerDuration
+	}
+	if duration > maxTriggerDuration {
+		duration = maxTriggerDuration
+	}
+	ptConfigs := make([]common.ProfileTypeConfig, 0, len(types))
+	for _, pt := range types {
+		ptCfg, ok := drivers.PresetProfileTypeConfig(pt, int64(duration/time.Second))
+		if !ok {
+			return "", fmt.Errorf("%w: %s", ErrorUnknownProfile, pt)
+		}
+		ptConfigs = append(ptConfigs, ptCfg)
+	}
+	task := &common.Task{
+		UploadID:     utils.NewRandID(),
+		ProfileTypes: types,
+		PtConfigs:    ptConfigs,
+		Reason:       reason,
+	}
+
+	p.triggerLock.Lock()
+	defer p.triggerLock.Unlock()
+	if time.Since(p.lastTriggerTime) < minTriggerInterval {
+		return "", ErrorTriggerTooFrequent
+	}
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.stopped {
+		return "", ErrorProfilerStopped
+	}
+	select {
+	case p.taskChan <- task: // non-blocking, otherwise Stop is blocked by lock
+		p.lastTriggerTime = time.Now()
+		p.logger.Info("[TriggerProfile] task is %+v", task)
+		return task.UploadID, nil
+	default:
+		return "", ErrorProfilerBusy
+	}
+}
+
 func (p *Profiler) runLoop() {
 	for {
 		select {
@@ -273,6 +395,10 @@ func (p *Profiler) runLoop() {
 func (p *Profiler) run(task *common.Task) {
 	task.StartTimeMilliSec = time.Now().Unix()*1e3 + int64(time.Now().Nanosecond())/1e6 // record the timestamp when task begin running
 
+	if task.DumpRecent && p.recorder != nil {
+		p.sendRecent(task, p.recorder.dump())
+	}
+
 	wg := sync.WaitGroup{}
 	l := sync.Mutex{}
 	profiles := make([]*common.ProfileData, 0)
@@ -308,6 +434,20 @@ func (p *Profiler) run(task *common.Task) {
 	p.send(task, profiles) // send must complete before close outChan
 }
 
+// sendRecent sends profiles recorded before task. Each recording is sent as a task linked to task by TriggerUploadID
+func (p *Profiler) sendRecent(task *common.Task, recordings []*recording) {
+	for _, rec := range recordings {
+		p.send(&common.Task{
+			ProfileID:         task.ProfileID,
+			UploadID:          utils.NewRandID(),
+			PtConfigs:         flightRecorderConfigs,
+			StartTimeMilliSec: rec.startTimeMilliSec,
+			EndTimeMilliSec:   rec.endTimeMilliSec,
+			TriggerUploadID:   task.UploadID,
+		}, rec.profiles)
+	}
+}
+
 func (p *Profiler) send(task *common.Task, batchProfileData []*common.ProfileData) {
 	if len(batchProfileData) == 0 {
 		p.logger.Info("send profileInfo.UploadInfo abort! empty data")
@@ -334,8 +474,15 @@ func (p *Profiler) send(task *common.Task, batchProfileData []*common.ProfileDat
 			CpuLimit:             int64(res_monitor.GetCPULimit()),
 			ProcessCpuUsageRatio: p.resMonitor.GetPastCPURatio(duration),
 			ProcessMemRssRatio:   p.resMonitor.GetPastMemRatio(duration),
+			HasPprofLabels:       hasPprofLabels(batchProfileData),
+			TriggerUploadId:      task.TriggerUploadID,
+			TriggerReason:        task.Reason,
 		},
 	}
+	if task.TriggerUploadID != "" { // resource usage of past duration does not match recorded profiles
+		profileInfo.UploadInfo.ProcessCpuUsageRatio = 0
+		profileInfo.UploadInfo.ProcessMemRssRatio = 0
+	}
 	for _, profileData := range batchProfileData {
 		if b, err := profileData.Marshal(); err == nil && len(b) != 0 {
 			profileInfo.MultiData = append(profileInfo.MultiData, b)
@@ -0,0 +1,100 @@
+package res_monitor
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+)
+
+const (
+	KeyCPURatio     = "cpu_ratio"
+	KeyMemRatio     = "mem_ratio"
+	KeyGoroutineNum = "goroutine_num"
+)
+
+var ErrorInvalidGaugeKey = errors.New("invalid gauge key")
+
+// Gauge returns current value of a custom resource, like queue depth, p99 latency, gc pause or open fds.
+// It is called every 10 seconds by monitors, so it should be fast and concurrent safe
+type Gauge func() float64
+
+var (
+	gaugesLock sync.RWMutex
+	gauges     = make(map[string]Gauge)
+)
+
+// RegisterGauge registers gauge with key, which is sampled by all monitors along with builtin resources.
+// Registering an existing key replaces the gauge. Builtin keys can not be registered
+func RegisterGauge(key string, g Gauge) error {
+	if key == "" || g == nil || key == KeyCPURatio || key == KeyMemRatio || key == KeyGoroutineNum {
+		return ErrorInvalidGaugeKey
+	}
+	gaugesLock.Lock()
+	defer gaugesLock.Unlock()
+	gauges[key] = g
+	return nil
+}
+
+// UnregisterGauge removes gauge of key, and its values are removed from monitors on next sampling
+func UnregisterGauge(key string) {
+	gaugesLock.Lock()
+	defer gaugesLock.Unlock()
+	delete(gauges, key)
+}
+
+// sampleGauges skips gauges which panic, so that their series are removed instead of crashing the process
+func sampleGauges(l logger.Logger) map[string]float64 {
+	gaugesLock.RLock()
+	registered := make(map[string]Gauge, len(gauges))
+	for key, g := range gauges {
+		registered[key] = g
+	}
+	gaugesLock.RUnlock()
+	values := make(map[string]float64, len(registered)+3)
+	for key, g := range registered { // gauges are called without lock
+		if v, ok := callGauge(key, g, l); ok {
+			values[key] = v
+		}
+	}
+	return values
+}
+
+func callGauge(key string, g Gauge, l logger.Logger) (v float64, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.Error("[sampleGauges] gauge %s panic: %v", key, r)
+			ok = false
+		}
+	}()
+	return g(), true
+}
+
+// Values are sampled values of a resource
+type Values struct {
+	Current float64
+	Delta   float64 // change ratio compared with average of last window, 0 before enough samples
+	Rate    float64 // change per second compared with the sample a window ago, 0 before enough samples
+}
+
+// series keeps sampled values of a resource, the same way as builtin resources
+type series struct {
+	values   Values
+	previous [reserveCount]float64
+	cnt      int64
+}
+
+func (s *series) add(v float64) {
+	s.cnt++
+	s.values.Current = v
+	if s.cnt >= reserveCount { // cold start
+		s.values.Delta = calDelta(v, s.previous[:compareCount])
+	}
+	if s.cnt > compareCount {
+		s.values.Rate = (v - s.previous[compareCount-1]) / float64(compareCount*resourceCheckPeriodSec)
+	}
+	for idx := reserveCount - 1; idx >= 1; idx-- {
+		s.previous[idx] = s.previous[idx-1]
+	}
+	s.previous[0] = v
+}
@@ -0,0 +1,42 @@
+package res_monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGauge(t *testing.T) {
+	assert.Equal(t, ErrorInvalidGaugeKey, RegisterGauge(KeyCPURatio, func() float64 { return 0 }))
+	assert.Equal(t, ErrorInvalidGaugeKey, RegisterGauge("", func() float64 { return 0 }))
+
+	depth := float64(0)
+	assert.Nil(t, RegisterGauge("queue_depth", func() float64 {
+		depth += 10
+		return depth
+	}))
+	m := NewMonitor()
+	for i := 0; i < reserveCount; i++ {
+		m.update()
+	}
+	values, ok := m.GetValues("queue_depth")
+	assert.True(t, ok)
+	assert.Equal(t, float64(120), values.Current)
+	assert.Equal(t, float64(1), values.Rate)             // (120 - 70) / 50s
+	assert.InDelta(t, (120.0-90)/90, values.Delta, 1e-9) // average of last window is 90
+	_, ok = m.GetValues(KeyGoroutineNum)
+	assert.True(t, ok)
+
+	UnregisterGauge("queue_depth")
+	m.update()
+	_, ok = m.GetValues("queue_depth")
+	assert.False(t, ok)
+
+	assert.Nil(t, RegisterGauge("broken", func() float64 { panic("broken gauge") }))
+	defer UnregisterGauge("broken")
+	assert.NotPanics(t, m.update)
+	_, ok = m.GetValues("broken")
+	assert.False(t, ok)
+	_, ok = m.GetValues(KeyGoroutineNum)
+	assert.True(t, ok)
+}
@@ -6,6 +6,8 @@ import (
 	"sync/atomic"
 	"time"
 	"unsafe"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
 )
 
 const (
@@ -37,8 +39,13 @@ type Monitor struct {
 
 	cnt int64
 
+	// builtin and custom resources by key
+	series map[string]*series
+
 	l sync.RWMutex
 
+	logger logger.Logger
+
 	closeChan chan struct{}
 	wg        sync.WaitGroup
 }
@@ -49,10 +56,21 @@ func NewMonitor() *Monitor {
 		memMonitor:       NewMemMonitor(),
 		goRoutineMonitor: NewGoRoutineMonitor(),
 
+		series: make(map[string]*series),
+
+		logger: &logger.NoopLogger{},
+
 		closeChan: make(chan struct{}),
 	}
 }
 
+// SetLogger sets logger used to report panicking gauges. It should be called before Start
+func (r *Monitor) SetLogger(l logger.Logger) {
+	if l != nil {
+		r.logger = l
+	}
+}
+
 func (r *Monitor) Start() {
 	r.update()
 	tc := time.NewTicker(resourceCheckPeriodTime)
@@ -86,6 +104,11 @@ func (r *Monitor) update() {
 	memRatio := r.memMonitor.GetMemRatio()
 	goroutineNum := float64(r.goRoutineMonitor.GetGoRoutineNum())
 
+	values := sampleGauges(r.logger)
+	values[KeyCPURatio] = cpuRatio
+	values[KeyMemRatio] = memRatio
+	values[KeyGoroutineNum] = goroutineNum
+
 	// assign
 	atomicStoreFloat64(&r.cpuRatio, cpuRatio)
 	atomicStoreFloat64(&r.memRatio, memRatio)
@@ -109,6 +132,20 @@ func (r *Monitor) update() {
 	r.previousCPURatio[0] = r.cpuRatio
 	r.previousMemRatio[0] = r.memRatio
 	r.previousGoroutineNum[0] = r.goroutineNum
+
+	for key, v := range values {
+		s, ok := r.series[key]
+		if !ok {
+			s = &series{}
+			r.series[key] = s
+		}
+		s.add(v)
+	}
+	for key := range r.series {
+		if _, ok := values[key]; !ok { // unregistered
+			delete(r.series, key)
+		}
+	}
 }
 
 func (r *Monitor) GetCPURatio() float64 {
@@ -138,6 +175,17 @@ func (r *Monitor) GetGoRoutineNumDelta() float64 {
 	return atomicLoadFloat64(&r.goroutineNumDelta)
 }
 
+// GetValues get sampled values of builtin or registered resource by key
+func (r *Monitor) GetValues(key string) (Values, bool) {
+	r.l.RLock()
+	defer r.l.RUnlock()
+	s, ok := r.series[key]
+	if !ok {
+		return Values{}, false
+	}
+	return s.values, true
+}
+
 // GetPastCPURatio get past avg cpu_ratio in last n seconds
 func (r *Monitor) GetPastCPURatio(sec int64) float64 {
 	rightIdx := getRightIndex(sec)
@@ -177,6 +177,9 @@ func writeFiled(w *multipart.Writer, info *profile_models.UploadInfo) {
 	_ = w.WriteField("cpu_limit", strconv.FormatInt(info.CpuLimit, 10))
 	_ = w.WriteField("process_cpu_usage_ratio", strconv.FormatFloat(info.ProcessCpuUsageRatio, 'f', 6, 64))
 	_ = w.WriteField("process_mem_rss_ratio", strconv.FormatFloat(info.ProcessMemRssRatio, 'f', 6, 64))
+	_ = w.WriteField("has_pprof_labels", strconv.FormatBool(info.HasPprofLabels))
+	_ = w.WriteField("trigger_upload_id", info.TriggerUploadId)
+	_ = w.WriteField("trigger_reason", info.TriggerReason)
 }
 
 func (s *HTTPSender) sendRequest(request *http.Request) (bool, time.Duration) {
@@ -11,6 +11,11 @@ const (
 	Redis   = "redis"
 	Kafka   = "kafka"
 	Mongodb = "mongodb"
+
+	PostgreSQL = "postgresql"
+	SQLServer  = "sqlserver"
+	SQLite     = "sqlite"
+	ClickHouse = "clickhouse"
 )
 
 // log level
@@ -35,6 +40,7 @@ const (
 
 const (
 	DbStatement = "db.statement"
+	DbSlowQuery = "db.slow_query"
 )
 
 const (
@@ -0,0 +1,106 @@
+package aitracer
+
+import (
+	"regexp"
+	"strconv"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/settings_fetcher/settings_models"
+)
+
+const httpErrorCodeThreshold = 400
+
+// ErrorPolicy decides which http status codes and rpc codes are errors, and which errors are direct causes of crash.
+// It is from BusinessError of remote settings. Before settings are fetched or if codes are not set, http status codes >= 400
+// and all rpc errors are errors.
+type ErrorPolicy struct {
+	httpErrorCodes map[int]bool
+	rpcErrorCodes  map[string]bool
+
+	crashClassNames map[string]bool
+	crashPattern    *regexp.Regexp
+}
+
+var defaultErrorPolicy = &ErrorPolicy{}
+
+func newErrorPolicy(businessError *settings_models.BusinessError) (*ErrorPolicy, error) {
+	p := &ErrorPolicy{
+		httpErrorCodes:  make(map[int]bool),
+		rpcErrorCodes:   make(map[string]bool),
+		crashClassNames: make(map[string]bool),
+	}
+	for _, code := range businessError.HttpErrorCodes {
+		p.httpErrorCodes[int(code)] = true
+	}
+	for _, code := range businessError.RpcErrorCodes {
+		p.rpcErrorCodes[code] = true
+	}
+	if clazz := businessError.DirectCauseCrashClazz; clazz != nil {
+		for _, name := range clazz.ClassNames {
+			p.crashClassNames[name] = true
+		}
+		if clazz.Pattern != "" {
+			re, err := regexp.Compile(clazz.Pattern)
+			if err != nil {
+				return p, err
+			}
+			p.crashPattern = re
+		}
+	}
+	return p, nil
+}
+
+// IsHttpError returns true if statusCode is in HttpErrorCodes, or statusCode >= 400 if HttpErrorCodes is not set
+func (p *ErrorPolicy) IsHttpError(statusCode int) bool {
+	if p == nil || len(p.httpErrorCodes) == 0 {
+		return statusCode >= httpErrorCodeThreshold
+	}
+	return p.httpErrorCodes[statusCode]
+}
+
+// IsRpcError returns true if any of codes is in RpcErrorCodes, or always true if RpcErrorCodes is not set.
+// codes are different forms of the same code, like "14" and "Unavailable" of grpc.
+// It should be called only for failed calls.
+func (p *ErrorPolicy) IsRpcError(codes ...string) bool {
+	if p == nil || len(p.rpcErrorCodes) == 0 {
+		return true
+	}
+	for _, code := range codes {
+		if p.rpcErrorCodes[code] {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCrashCause returns true if type of err, like *os.PathError, is in ClassNames or matches Pattern of DirectCauseCrashClazz
+func (p *ErrorPolicy) IsCrashCause(err interface{}) bool {
+	if p == nil || err == nil {
+		return false
+	}
+	return p.isCrashCauseType(getErrorType(err))
+}
+
+func (p *ErrorPolicy) isCrashCauseType(errorType string) bool {
+	if p == nil {
+		return false
+	}
+	if p.crashClassNames[errorType] {
+		return true
+	}
+	return p.crashPattern != nil && p.crashPattern.MatchString(errorType)
+}
+
+// HttpCodeError is recorded with ErrorKindHttpCodeError when http status code is error by ErrorPolicy
+type HttpCodeError int
+
+func (e HttpCodeError) Error() string {
+	return "http status code " + strconv.Itoa(int(e))
+}
+
+func (t *tracer) ErrorPolicy() *ErrorPolicy {
+	dynamicConfig := t.getDynamicConfig()
+	if dynamicConfig == nil || dynamicConfig.ErrorPolicy == nil {
+		return defaultErrorPolicy
+	}
+	return dynamicConfig.ErrorPolicy
+}
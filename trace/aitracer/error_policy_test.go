@@ -0,0 +1,45 @@
+package aitracer
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/settings_fetcher/settings_models"
+)
+
+func TestErrorPolicy(t *testing.T) {
+	tr := NewTracer(Http, "svc").(*tracer)
+	p := tr.ErrorPolicy()
+	assert.True(t, p.IsHttpError(500))
+	assert.False(t, p.IsHttpError(302))
+	assert.True(t, p.IsRpcError("14"))
+	assert.False(t, p.IsCrashCause(errors.New("err")))
+
+	tr.handleSettingsForDynamicConfig(&settings_models.Settings{BusinessError: &settings_models.BusinessError{
+		HttpErrorCodes: []int32{500, 502},
+		RpcErrorCodes:  []string{"Unavailable"},
+		DirectCauseCrashClazz: &settings_models.CrashClazz{
+			ClassNames: []string{"*errors.errorString"},
+			Pattern:    `PathError$`,
+		},
+	}})
+	p = tr.ErrorPolicy()
+	assert.True(t, p.IsHttpError(502))
+	assert.False(t, p.IsHttpError(404))
+	assert.True(t, p.IsRpcError("14", "Unavailable"))
+	assert.False(t, p.IsRpcError("5", "NotFound"))
+	assert.True(t, p.IsCrashCause(errors.New("err")))
+	assert.True(t, p.IsCrashCause(&os.PathError{}))
+	assert.False(t, p.IsCrashCause(HttpCodeError(500)))
+
+	s := tr.StartServerSpan("request")
+	s.RecordError(&os.PathError{Op: "open", Err: os.ErrNotExist})
+	s.RecordError(HttpCodeError(500))
+	ss := s.(*span)
+	assert.Equal(t, "1", ss.ErrorInfoList[0].ErrorTags[internal.DirectCauseCrash])
+	_, ok := ss.ErrorInfoList[1].ErrorTags[internal.DirectCauseCrash]
+	assert.False(t, ok)
+}
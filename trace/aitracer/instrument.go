@@ -0,0 +1,358 @@
+package aitracer
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/settings_fetcher/settings_models"
+)
+
+const (
+	aiCustomThroughput = "apminsight.service.trace.custom.throughput"
+	aiCustomLatency    = "apminsight.service.trace.custom.latency.us"
+
+	codeNamespace = "code.namespace"
+	codeFunction  = "code.function"
+
+	defaultCollectedObjectDepth = 1
+	maxCollectedElements        = 16
+	maxCollectedValueLength     = 1024
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// customInstrument is rule from TraceCustomInstrument settings
+type customInstrument struct {
+	collectArgs   bool
+	collectReturn bool
+	collectMetric bool
+	argTypes      map[string]bool // empty means all types
+	objectDepth   int
+}
+
+// newCustomInstruments returns rules keyed by ClassName.MethodName, MethodName is * for all methods of class.
+// Settings of the same class with different methods are all kept
+func newCustomInstruments(configs []*settings_models.TraceCustomInstrument) map[string]*customInstrument {
+	instruments := make(map[string]*customInstrument)
+	for _, c := range configs {
+		if c == nil {
+			continue
+		}
+		ci := &customInstrument{
+			collectArgs:   c.IsCollectArgs,
+			collectReturn: c.IsCollectReturn,
+			collectMetric: c.IsCollectMetric,
+			argTypes:      make(map[string]bool),
+			objectDepth:   int(c.CollectedObjectDepth),
+		}
+		for _, t := range c.CollectedArgTypes {
+			ci.argTypes[t] = true
+		}
+		if ci.objectDepth <= 0 {
+			ci.objectDepth = defaultCollectedObjectDepth
+		}
+		methods := c.MethodName
+		if len(methods) == 0 {
+			methods = []string{"*"}
+		}
+		for _, m := range methods {
+			instruments[c.ClassName+"."+m] = ci
+		}
+	}
+	return instruments
+}
+
+func (t *tracer) getCustomInstrument(className, methodName string) *customInstrument {
+	dynamicConfig := t.getDynamicConfig()
+	if dynamicConfig == nil {
+		return nil
+	}
+	if ci, ok := dynamicConfig.CustomInstruments[className+"."+methodName]; ok {
+		return ci
+	}
+	return dynamicConfig.CustomInstruments[className+".*"]
+}
+
+// Instrument wraps fn, which is traced when enabled by TraceCustomInstrument settings, so that a function can be traced without redeploy.
+// name is like pkg.Type.Method or pkg.Func, which is split by the last "." into ClassName and MethodName of settings.
+// fn must be a func, and the returned value has the same type as fn:
+//
+//	query := aitracer.Instrument("dao.UserDao.Query", dao.Query).(func(int64) (*User, error))
+//
+// Span is started without parent, use InstrumentCtx for fn with context.Context as first argument.
+// Global tracer is used, fn is called directly if global tracer is not set or fn is not enabled.
+func Instrument(name string, fn interface{}) interface{} {
+	return instrument(name, fn, false)
+}
+
+// InstrumentCtx is like Instrument, but fn must take context.Context as first argument.
+// Span is started as child of span in ctx and ctx passed to fn contains the new span.
+func InstrumentCtx(name string, fn interface{}) interface{} {
+	return instrument(name, fn, true)
+}
+
+func instrument(name string, fn interface{}, withCtx bool) interface{} {
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func {
+		panic("aitracer: fn of Instrument is not a func")
+	}
+	ft := fv.Type()
+	if withCtx && (ft.NumIn() == 0 || ft.In(0) != contextType) {
+		panic("aitracer: first argument of fn of InstrumentCtx is not context.Context")
+	}
+	className, methodName := "", name
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		className, methodName = name[:i], name[i+1:]
+	}
+
+	return reflect.MakeFunc(ft, func(args []reflect.Value) []reflect.Value {
+		t, _ := GlobalTracer().(*tracer)
+		if t == nil {
+			return callFunc(fv, args)
+		}
+		ci := t.getCustomInstrument(className, methodName)
+		if ci == nil {
+			return callFunc(fv, args)
+		}
+
+		var span Span
+		argStart := 0
+		if withCtx {
+			ctx, _ := args[0].Interface().(context.Context)
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			span, ctx = t.StartSpanFromContext(ctx, name)
+			args[0] = reflect.ValueOf(&ctx).Elem() // keep type of context.Context
+			argStart = 1
+		} else {
+			span = t.StartSpan(name)
+		}
+		span.SetTagString(codeNamespace, className)
+		span.SetTagString(codeFunction, methodName)
+		if ci.collectArgs {
+			for i := argStart; i < len(args); i++ {
+				if ci.collectArgType(args[i]) {
+					span.SetTagString("arg."+strconv.Itoa(i-argStart), formatValue(args[i], ci.objectDepth))
+				}
+			}
+		}
+
+		var (
+			results  []reflect.Value
+			returned bool // false if fn panics
+		)
+		start := time.Now()
+		defer func() {
+			if !ci.collectMetric {
+				return
+			}
+			var status int64 = StatusCodeOK
+			if !returned || hasError(results) {
+				status = StatusCodeError
+			}
+			t.emitCustomMetric(className, methodName, status, time.Since(start))
+		}()
+		defer span.Finish()
+
+		results = callFunc(fv, args)
+		returned = true
+		if hasError(results) {
+			err, _ := results[len(results)-1].Interface().(error)
+			span.RecordError(err)
+			span.SetStatus(StatusCodeError)
+		}
+		if ci.collectReturn {
+			for i, r := range results {
+				span.SetTagString("return."+strconv.Itoa(i), formatValue(r, ci.objectDepth))
+			}
+		}
+		return results
+	}).Interface()
+}
+
+func callFunc(fv reflect.Value, args []reflect.Value) []reflect.Value {
+	if fv.Type().IsVariadic() {
+		return fv.CallSlice(args)
+	}
+	return fv.Call(args)
+}
+
+// hasError returns true if the last result is a non-nil error
+func hasError(results []reflect.Value) bool {
+	if len(results) == 0 {
+		return false
+	}
+	last := results[len(results)-1]
+	return last.Type() == errorType && !last.IsNil()
+}
+
+// collectArgType returns true if static or dynamic type of arg is in CollectedArgTypes
+func (ci *customInstrument) collectArgType(arg reflect.Value) bool {
+	if len(ci.argTypes) == 0 {
+		return true
+	}
+	if ci.argTypes[arg.Type().String()] {
+		return true
+	}
+	if arg.Kind() == reflect.Interface && !arg.IsNil() {
+		return ci.argTypes[arg.Elem().Type().String()]
+	}
+	return false
+}
+
+func (t *tracer) emitCustomMetric(className, methodName string, status int64, duration time.Duration) {
+	mc := t.metricsClient
+	if mc == nil {
+		return
+	}
+	tags := map[string]string{
+		"service_type": t.serviceType,
+		"service":      t.service,
+		"instance_id":  t.instanceId,
+		"class_name":   className,
+		"method_name":  methodName,
+		"status":       strconv.FormatInt(status, 10),
+	}
+	_ = mc.EmitCounter(aiCustomThroughput, 1, tags)
+	_ = mc.EmitTimer(aiCustomLatency, float64(duration.Microseconds()), tags)
+}
+
+// formatValue formats v to string. structs, maps and slices are expanded up to depth levels,
+// and at most 16 elements are formatted for each of them. Type name is returned if Error or String of v panics
+func formatValue(v reflect.Value, depth int) (s string) {
+	defer func() {
+		if r := recover(); r != nil { // like String of typed nil pointer in interface
+			s = "nil"
+			if v.IsValid() {
+				s = v.Type().String()
+			}
+		}
+	}()
+	var sb strings.Builder
+	writeValue(&sb, v, depth)
+	s = sb.String()
+	if len(s) > maxCollectedValueLength {
+		n := maxCollectedValueLength
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		s = s[:n]
+	}
+	return s
+}
+
+func writeValue(sb *strings.Builder, v reflect.Value, depth int) {
+	if !v.IsValid() {
+		sb.WriteString("nil")
+		return
+	}
+	if (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) && v.IsNil() {
+		sb.WriteString("nil")
+		return
+	}
+	if v.CanInterface() {
+		switch i := v.Interface().(type) {
+		case error:
+			sb.WriteString(i.Error())
+			return
+		case fmt.Stringer:
+			sb.WriteString(i.String())
+			return
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		writeValue(sb, v.Elem(), depth)
+	case reflect.Struct:
+		if depth <= 0 {
+			sb.WriteString(v.Type().String())
+			return
+		}
+		sb.WriteByte('{')
+		for i := 0; i < v.NumField(); i++ {
+			if i >= maxCollectedElements {
+				sb.WriteString(" ...")
+				break
+			}
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(v.Type().Field(i).Name)
+			sb.WriteByte(':')
+			writeValue(sb, v.Field(i), depth-1)
+		}
+		sb.WriteByte('}')
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			sb.WriteString(fmt.Sprintf("[%d bytes]", v.Len()))
+			return
+		}
+		if depth <= 0 {
+			sb.WriteString(fmt.Sprintf("%s[len=%d]", v.Type().String(), v.Len()))
+			return
+		}
+		sb.WriteByte('[')
+		for i := 0; i < v.Len(); i++ {
+			if i >= maxCollectedElements {
+				sb.WriteString(" ...")
+				break
+			}
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			writeValue(sb, v.Index(i), depth-1)
+		}
+		sb.WriteByte(']')
+	case reflect.Map:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return
+		}
+		if depth <= 0 {
+			sb.WriteString(fmt.Sprintf("%s[len=%d]", v.Type().String(), v.Len()))
+			return
+		}
+		sb.WriteString("map[")
+		iter := v.MapRange()
+		for i := 0; iter.Next(); i++ {
+			if i >= maxCollectedElements {
+				sb.WriteString(" ...")
+				break
+			}
+			if i > 0 {
+				sb.WriteByte(' ')
+			}
+			writeValue(sb, iter.Key(), depth-1)
+			sb.WriteByte(':')
+			writeValue(sb, iter.Value(), depth-1)
+		}
+		sb.WriteByte(']')
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		sb.WriteString(v.Type().String())
+	case reflect.String:
+		sb.WriteString(v.String())
+	case reflect.Bool:
+		sb.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sb.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sb.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		sb.WriteString(strconv.FormatFloat(v.Float(), 'g', -1, 64))
+	default:
+		sb.WriteString(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,113 @@
+package aitracer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/settings_fetcher/settings_models"
+)
+
+type panicStringer struct {
+	name string
+}
+
+func (p *panicStringer) String() string {
+	return p.name
+}
+
+type instrumentUser struct {
+	Name string
+	Tags []string
+}
+
+func TestInstrument(t *testing.T) {
+	prev := globalTracer
+	defer func() { globalTracer = prev }()
+	tr := NewTracer(Http, "svc").(*tracer)
+	SetGlobalTracer(tr)
+
+	var spanInCtx Span
+	query := func(ctx context.Context, id int64, u *instrumentUser) (string, error) {
+		spanInCtx = GetSpanFromContext(ctx)
+		if id < 0 {
+			return "", errors.New("invalid id")
+		}
+		return u.Name, nil
+	}
+	wrapped := InstrumentCtx("dao.UserDao.Query", query).(func(context.Context, int64, *instrumentUser) (string, error))
+	u := &instrumentUser{Name: "a", Tags: []string{"x"}}
+
+	// not enabled
+	name, err := wrapped(context.Background(), 1, u)
+	assert.Nil(t, err)
+	assert.Equal(t, "a", name)
+	assert.Equal(t, 0, len(tr.traceChan))
+	assert.Nil(t, spanInCtx)
+
+	tr.handleSettingsForDynamicConfig(&settings_models.Settings{Trace: &settings_models.Trace{
+		CustomInstrumentConfig: []*settings_models.TraceCustomInstrument{{
+			ClassName:         "dao.UserDao",
+			MethodName:        []string{"Query"},
+			IsCollectArgs:     true,
+			IsCollectReturn:   true,
+			CollectedArgTypes: []string{"*aitracer.instrumentUser"},
+		}},
+	}})
+	_, err = wrapped(context.Background(), -1, u)
+	assert.NotNil(t, err)
+	assert.NotNil(t, spanInCtx)
+
+	trace := <-tr.traceChan
+	span := trace.Spans[0]
+	assert.Equal(t, "dao.UserDao.Query", span.OperationName)
+	assert.Equal(t, int64(StatusCodeError), span.Status)
+	assert.Equal(t, "{Name:a Tags:[]string[len=1]}", span.ParamString["arg.1"])
+	_, ok := span.ParamString["arg.0"] // int64 is not in CollectedArgTypes
+	assert.False(t, ok)
+	assert.Equal(t, "invalid id", span.ParamString["return.1"])
+	assert.Equal(t, "invalid id", span.ErrorInfoList[0].ErrorMessage)
+
+	// other method of class is not enabled
+	sum := Instrument("dao.UserDao.Sum", func(nums ...int) int {
+		s := 0
+		for _, n := range nums {
+			s += n
+		}
+		return s
+	}).(func(...int) int)
+	assert.Equal(t, 6, sum(1, 2, 3))
+	assert.Equal(t, 0, len(tr.traceChan))
+
+	// settings of the same class are all kept
+	tr.handleSettingsForDynamicConfig(&settings_models.Settings{Trace: &settings_models.Trace{
+		CustomInstrumentConfig: []*settings_models.TraceCustomInstrument{
+			{ClassName: "dao.UserDao", MethodName: []string{"Query"}},
+			{ClassName: "dao.UserDao", MethodName: []string{"Sum"}},
+		},
+	}})
+	_, err = wrapped(context.Background(), 1, u)
+	assert.Nil(t, err)
+	assert.Equal(t, "dao.UserDao.Query", (<-tr.traceChan).Spans[0].OperationName)
+	assert.Equal(t, 6, sum(1, 2, 3))
+	assert.Equal(t, "dao.UserDao.Sum", (<-tr.traceChan).Spans[0].OperationName)
+}
+
+func TestFormatValue(t *testing.T) {
+	u := instrumentUser{Name: "a", Tags: []string{"x", "y"}}
+	assert.Equal(t, "{Name:a Tags:[x y]}", formatValue(reflect.ValueOf(u), 2))
+	assert.Equal(t, "aitracer.instrumentUser", formatValue(reflect.ValueOf(u), 0))
+	assert.Equal(t, "map[k:1]", formatValue(reflect.ValueOf(map[string]int{"k": 1}), 1))
+	assert.Equal(t, "[3 bytes]", formatValue(reflect.ValueOf([]byte("abc")), 1))
+	assert.Equal(t, "nil", formatValue(reflect.ValueOf((*instrumentUser)(nil)), 1))
+	var stringer fmt.Stringer = (*panicStringer)(nil)
+	assert.Equal(t, "fmt.Stringer", formatValue(reflect.ValueOf(&stringer).Elem(), 1)) // typed nil passes IsNil check
+	long := formatValue(reflect.ValueOf(strings.Repeat("中", maxCollectedValueLength)), 1)
+	assert.True(t, utf8.ValidString(long))
+	assert.True(t, len(long) <= maxCollectedValueLength)
+}
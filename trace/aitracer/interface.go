@@ -3,9 +3,11 @@ package aitracer
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sampler"
 )
 
 type StartSpanConfig struct {
@@ -18,6 +20,16 @@ type StartSpanConfig struct {
 	ClientResource    string
 	ClientService     string
 	ClientServiceType string
+
+	StartTime time.Time
+
+	Links []SpanLink
+}
+
+// SpanLink links span to another span, which is usually in other traces, like producers of messages consumed in batch
+type SpanLink struct {
+	SpanContext SpanContext
+	Attributes  map[string]string
 }
 
 type StartSpanOption func(*StartSpanConfig)
@@ -86,6 +98,11 @@ type Span interface {
 
 	RecordError(err error, opt ...RecordOption)
 	SetStatus(status int64)
+
+	// AddEvent records an event happened during span. timestamp is now if zero
+	AddEvent(name string, attrs map[string]string, timestamp time.Time)
+	// AddLink links span to sc, see SpanLink
+	AddLink(sc SpanContext, attrs map[string]string)
 }
 
 type SampleStrategy byte
@@ -151,8 +168,9 @@ type SpanContext interface {
 }
 
 var (
-	ErrUnsupportedFormat = errors.New("Unknown or unsupported Inject/Extract format")
-	ErrInvalidCarrier    = errors.New("Invalid Inject/Extract carrier")
+	ErrUnsupportedFormat  = errors.New("Unknown or unsupported Inject/Extract format")
+	ErrInvalidCarrier     = errors.New("Invalid Inject/Extract carrier")
+	ErrInvalidSpanContext = errors.New("Invalid SpanContext")
 )
 
 type Injector interface {
@@ -180,8 +198,12 @@ type TracerConfig struct {
 
 	Logger logger.Logger
 
-	EnableMetric bool
-	MetricSock   string
+	EnableMetric      bool
+	MetricSock        string
+	MetricAggregation bool // aggregate span metrics in client, latencies are sent as histograms
+
+	EnablePrometheus    bool // expose metrics by PrometheusHandler
+	DisableMetricSender bool // do not send metrics to server-agent or OTLP collector
 
 	EnableLogSender bool
 	LogSenderDebug  bool // for safety, can not use incoming logger.Logger in LogCollector
@@ -193,13 +215,35 @@ type TracerConfig struct {
 
 	EnableRuntimeMetric bool
 
+	EnableSelfStatsMetric bool
+
 	SettingsFetcherSock string
 
 	PropagatorConfigs []PropagatorConfig
+	CompositeFormats  []interface{} // formats used by Composite, in extract priority order
 
 	ServerRegisterSock string
 
+	TailSampling TailSamplingConfig
+
+	SamplerRules []trace_sampler.SamplerRule
+
+	OTLP OTLPConfig
+
+	// send data to collector directly by http if HTTPHost is set
+	HTTPSchema  string
+	HTTPHost    string
+	HTTPTimeout time.Duration
+
+	// spill traces to disk when server-agent is unreachable or channel is full if SpillDir is set
+	SpillDir      string
+	SpillMaxBytes int64
+	SpillMaxAge   time.Duration
+
 	ContextAdapter func(context.Context) context.Context
+
+	// set trace_id, span_id and resource of server spans as pprof labels of goroutines, so that cpu profiles can be filtered by them
+	EnablePprofLabels bool
 }
 
 type TracerOption func(*TracerConfig)
@@ -247,6 +291,38 @@ func WithMetrics(enable bool) TracerOption {
 	}
 }
 
+// WithMetricsAggregation aggregates metrics of spans by name and tags in client before sending,
+// which reduces items sent to server-agent for services with high throughput
+func WithMetricsAggregation(enable bool) TracerOption {
+	return func(config *TracerConfig) {
+		config.MetricAggregation = enable
+	}
+}
+
+// WithPrometheus exposes span, runtime and self stats metrics by PrometheusHandler
+func WithPrometheus(enable bool) TracerOption {
+	return func(config *TracerConfig) {
+		config.EnablePrometheus = enable
+	}
+}
+
+// WithMetricSender enables sending metrics to server-agent or OTLP collector, which is enabled by default.
+// Disable it to expose metrics only by PrometheusHandler
+func WithMetricSender(enable bool) TracerOption {
+	return func(config *TracerConfig) {
+		config.DisableMetricSender = !enable
+	}
+}
+
+// WithPprofLabels sets trace_id, span_id and resource of server spans started by StartServerSpanFromContext as pprof labels
+// of goroutines, and restores labels to those of ctx when spans finish, so that cpu profiles of aiprofiler can be filtered
+// by endpoint and trace. Server spans should be finished in the goroutine where they started.
+func WithPprofLabels(enable bool) TracerOption {
+	return func(config *TracerConfig) {
+		config.EnablePprofLabels = enable
+	}
+}
+
 func WithMetricsAddress(metricAddress string) TracerOption {
 	return func(config *TracerConfig) {
 		config.MetricSock = metricAddress
@@ -271,6 +347,14 @@ func WithRuntimeMetric(enable bool) TracerOption {
 	}
 }
 
+// WithSelfStatsMetric emits stats of the SDK itself (see Stats) as apminsight.sdk.* counters every 30 seconds
+func WithSelfStatsMetric(enable bool) TracerOption {
+	return func(config *TracerConfig) {
+		config.EnableSelfStatsMetric = enable
+	}
+}
+
+// WithPropagator registers injector and extractor of format. Builtin format registered by default can be overridden
 func WithPropagator(format interface{}, injector Injector, extractor Extractor) TracerOption {
 	return func(config *TracerConfig) {
 		config.PropagatorConfigs = append(config.PropagatorConfigs, PropagatorConfig{
@@ -281,6 +365,74 @@ func WithPropagator(format interface{}, injector Injector, extractor Extractor)
 	}
 }
 
+// WithCompositeFormats set formats used by Composite format. Extract tries formats in order, Inject writes all of them.
+// Formats should be registered by default or through WithPropagator
+func WithCompositeFormats(formats ...interface{}) TracerOption {
+	return func(config *TracerConfig) {
+		config.CompositeFormats = formats
+	}
+}
+
+// WithSamplerRules set local sampler rules. Rules are matched in order and the first matched rule decides,
+// traces matching no rule are sampled by strategy from remote settings.
+// Rules from remote settings are matched before local rules.
+func WithSamplerRules(rules ...trace_sampler.SamplerRule) TracerOption {
+	return func(config *TracerConfig) {
+		config.SamplerRules = append(config.SamplerRules, rules...)
+	}
+}
+
+// WithTailSampling enables tail-based sampling. Traces not hit by sampler will still be sent
+// if any span has error, non-zero status or a duration longer than latencyThreshold.
+func WithTailSampling(latencyThreshold time.Duration) TracerOption {
+	return func(config *TracerConfig) {
+		config.TailSampling.Enable = true
+		config.TailSampling.LatencyThreshold = latencyThreshold
+	}
+}
+
+// WithTailSamplingResourceThreshold set latency threshold of tail-based sampling for specified resource
+func WithTailSamplingResourceThreshold(resource string, latencyThreshold time.Duration) TracerOption {
+	return func(config *TracerConfig) {
+		if config.TailSampling.ResourceLatencyThresholds == nil {
+			config.TailSampling.ResourceLatencyThresholds = make(map[string]time.Duration)
+		}
+		config.TailSampling.ResourceLatencyThresholds[resource] = latencyThreshold
+	}
+}
+
+// WithOTLPExporter exports traces, logs and metrics to OTLP collector by OTLP/HTTP (protobuf) instead of server-agent.
+// endpoint is base url of collector, like http://localhost:4318
+func WithOTLPExporter(endpoint string, headers map[string]string) TracerOption {
+	return func(config *TracerConfig) {
+		config.OTLP.Endpoint = endpoint
+		config.OTLP.Headers = headers
+	}
+}
+
+// WithHTTPEndPoint set http endpoint for settings, service register, traces and logs thus bypass server-agent,
+// which is useful where server-agent is not installed. App key is read from env APMPLUS_APP_KEY.
+// Traces and logs are still exported by OTLP if WithOTLPExporter is set
+func WithHTTPEndPoint(schema, host string, timeout time.Duration) TracerOption {
+	return func(config *TracerConfig) {
+		config.HTTPSchema = schema
+		config.HTTPHost = host
+		config.HTTPTimeout = timeout
+	}
+}
+
+// WithSpillBuffer enables a bounded on-disk queue under dir for traces, which stores batches while server-agent is unreachable
+// and traces dropped because sender channel is full. They are sent in order after connection recovers, also after restart.
+// maxBytes limits the total size, half for each of the two queues. Data not written for maxAge is evicted.
+// dir should not be shared by multiple tracers. It does not work with WithHTTPEndPoint or WithOTLPExporter
+func WithSpillBuffer(dir string, maxBytes int64, maxAge time.Duration) TracerOption {
+	return func(config *TracerConfig) {
+		config.SpillDir = dir
+		config.SpillMaxBytes = maxBytes
+		config.SpillMaxAge = maxAge
+	}
+}
+
 func WithContextAdapter(contextAdapter func(context.Context) context.Context) TracerOption {
 	return func(config *TracerConfig) {
 		config.ContextAdapter = contextAdapter
@@ -311,7 +463,34 @@ type Tracer interface {
 	StartSpanFromContext(ctx context.Context, operationName string, opts ...StartSpanOption) (Span, context.Context)
 
 	Log(ctx context.Context, data LogData)
+
+	// ErrorPolicy returns active ErrorPolicy from remote settings, which is used by integrations to decide whether a call fails
+	ErrorPolicy() *ErrorPolicy
+
+	// Flush sends traces, logs and metrics queued and buffered, and waits for them to be sent until ctx is done
+	Flush(ctx context.Context) error
+
 	Stop()
+	// StopWithContext is like Stop, but returns *StopError when ctx is done before all data is sent.
+	// Metrics and logs are given a short grace period if ctx is done while waiting for traces
+	StopWithContext(ctx context.Context) error
+}
+
+// StopError is returned by StopWithContext. Traces, Logs and Metrics are numbers of data dropped in channels,
+// and data in batches being sent are not counted
+type StopError struct {
+	Err     error
+	Traces  int
+	Logs    int
+	Metrics int
+}
+
+func (e *StopError) Error() string {
+	return fmt.Sprintf("aitracer: stop %v, %d traces, %d logs and %d metrics are dropped", e.Err, e.Traces, e.Logs, e.Metrics)
+}
+
+func (e *StopError) Unwrap() error {
+	return e.Err
 }
 
 // 使用context传播
@@ -354,3 +533,17 @@ func ClientResourceAs(clientServiceType string, clientService string, clientReso
 		config.ClientResource = clientResource
 	}
 }
+
+// LinkTo links span to sc at start. It can be used more than once to add multiple links
+func LinkTo(sc SpanContext, attrs map[string]string) StartSpanOption {
+	return func(config *StartSpanConfig) {
+		config.Links = append(config.Links, SpanLink{SpanContext: sc, Attributes: attrs})
+	}
+}
+
+// StartTimeAs set start time of span, which is useful when span is started after the operation. Default is now
+func StartTimeAs(startTime time.Time) StartSpanOption {
+	return func(config *StartSpanConfig) {
+		config.StartTime = startTime
+	}
+}
@@ -16,8 +16,8 @@ const (
 )
 
 const (
-	keyAppID  = "app_id"
-	keyOrigin = "origin"
+	KeyAppID  = "app_id"
+	KeyOrigin = "origin"
 )
 
 var supportedVersions = map[int]struct{}{0: {}}
@@ -137,7 +137,7 @@ func (w SimpleW3CFormatParser) ParseTraceState(traceState string) (TraceState, e
 
 func (t *TraceState) GetAppID() string {
 	for _, m := range t.Members {
-		if m.Key == keyAppID {
+		if m.Key == KeyAppID {
 			return m.Value
 		}
 	}
@@ -146,9 +146,93 @@ func (t *TraceState) GetAppID() string {
 
 func (t *TraceState) GetOrigin() string {
 	for _, m := range t.Members {
-		if m.Key == keyOrigin {
+		if m.Key == KeyOrigin {
 			return m.Value
 		}
 	}
 	return ""
 }
+
+// FormatTraceParent format traceParent like 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+func (w SimpleW3CFormatParser) FormatTraceParent(traceParent TraceParent) string {
+	sb := strings.Builder{}
+	sb.Grow(minLength)
+	sb.WriteString(hex.EncodeToString([]byte{byte(traceParent.Version)}))
+	sb.WriteString(parentDelimiter)
+	sb.WriteString(traceParent.TraceID)
+	sb.WriteString(parentDelimiter)
+	sb.WriteString(traceParent.ParentSpanID)
+	sb.WriteString(parentDelimiter)
+	sb.WriteString(hex.EncodeToString([]byte{byte(traceParent.TraceFlags)}))
+	return sb.String()
+}
+
+// FormatTraceState format traceState like k1=v1,k2=v2. members with empty key or value are ignored
+func (w SimpleW3CFormatParser) FormatTraceState(traceState TraceState) string {
+	sb := strings.Builder{}
+	cnt := 0
+	for _, m := range traceState.Members {
+		if m.Key == "" || m.Value == "" {
+			continue
+		}
+		if cnt >= maxMembers {
+			break
+		}
+		if cnt > 0 {
+			sb.WriteString(stateDelimiter)
+		}
+		sb.WriteString(m.Key)
+		sb.WriteString(stateEqual)
+		sb.WriteString(m.Value)
+		cnt++
+	}
+	return sb.String()
+}
+
+func (t *TraceState) Get(key string) string {
+	for _, m := range t.Members {
+		if m.Key == key {
+			return m.Value
+		}
+	}
+	return ""
+}
+
+// Set updates value of key, or appends a new member if key not exists
+func (t *TraceState) Set(key, value string) {
+	for i := range t.Members {
+		if t.Members[i].Key == key {
+			t.Members[i].Value = value
+			return
+		}
+	}
+	t.Members = append(t.Members, Member{Key: key, Value: value})
+}
+
+func (t *TraceState) SetAppID(appID string) {
+	t.Set(KeyAppID, appID)
+}
+
+func (t *TraceState) SetOrigin(origin string) {
+	t.Set(KeyOrigin, origin)
+}
+
+// IsValidTraceID checks traceID is 32 lowercase hex characters and not all zero
+func IsValidTraceID(traceID string) bool {
+	return len(traceID) == 32 && isLowerHex(traceID) && traceID != "00000000000000000000000000000000"
+}
+
+// IsValidSpanID checks spanID is 16 lowercase hex characters and not all zero
+func IsValidSpanID(spanID string) bool {
+	return len(spanID) == 16 && isLowerHex(spanID) && spanID != "0000000000000000"
+}
+
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -1,13 +1,18 @@
 package log_collector
 
 import (
+	"context"
 	"encoding/binary"
 	"sync"
 	"time"
 
+	"github.com/volcengine/apminsight-server-sdk-go/internal/flush"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/otlphttp"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/log_collector/log_models"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/agentless_adapter"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/sendworker"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/service_register/register_utils"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/utils"
@@ -16,6 +21,8 @@ import (
 const (
 	maxBatchBytes       = 16384
 	streamMaxBatchBytes = 64 << 10 //64KB
+
+	collectorPath = "/server_collect/log_collect"
 )
 
 type LogCollector struct {
@@ -32,6 +39,10 @@ type LogCollector struct {
 	tags []byte
 
 	ws []sendworker.SendWorker
+
+	otlp *otlphttp.Client // export by OTLP/HTTP instead of server-agent if set
+
+	flushReqs *flush.Requests
 }
 
 type LogCollectorConfig struct {
@@ -40,9 +51,31 @@ type LogCollectorConfig struct {
 	ChanSize     int
 	WorkerNumber int
 	Debug        bool
+
+	// send logs to collector directly by http if Host is set
+	Schema  string
+	Host    string
+	Timeout time.Duration
+
+	OTLP otlphttp.Config // export by OTLP/HTTP if Endpoint is set
 }
 
 func NewLogCollector(config LogCollectorConfig) *LogCollector {
+	if config.OTLP.Endpoint != "" {
+		if config.ChanSize <= 0 {
+			panic("channel size must be positive")
+		}
+		return newOTLPLogCollector(config)
+	}
+	if config.Host != "" {
+		if config.WorkerNumber <= 0 {
+			panic("worker must be positive")
+		}
+		if config.ChanSize <= 0 {
+			panic("channel size must be positive")
+		}
+		return newHTTPLogCollector(config)
+	}
 	if config.Sock == "" {
 		panic("socket address is empty")
 	}
@@ -74,6 +107,7 @@ func newDatagramLogCollector(config LogCollectorConfig) *LogCollector {
 	return &LogCollector{
 		logger:        l,
 		in:            make(chan *log_models.Log, config.ChanSize),
+		flushReqs:     flush.NewRequests(),
 		bufferMaxSize: maxBatchBytes, //16KB
 		offset:        0,             // do not need
 		flushInterval: time.Second,
@@ -98,6 +132,7 @@ func newStreamLogCollector(config LogCollectorConfig) *LogCollector {
 	return &LogCollector{
 		logger:        l,
 		in:            make(chan *log_models.Log, config.ChanSize),
+		flushReqs:     flush.NewRequests(),
 		bufferMaxSize: streamMaxBatchBytes,                   // 64KB
 		offset:        sendworker.GetPrefixLen(1, tagsBytes), // preallocate prefix
 		flushInterval: 5 * time.Second,
@@ -106,15 +141,58 @@ func newStreamLogCollector(config LogCollectorConfig) *LogCollector {
 	}
 }
 
+func newHTTPLogCollector(config LogCollectorConfig) *LogCollector {
+	var l logger.Logger
+	if config.Debug {
+		l = &logger.DebugLogger{}
+	} else {
+		l = &logger.NoopLogger{}
+	}
+	ws := make([]sendworker.SendWorker, 0, config.WorkerNumber)
+	for i := 0; i < config.WorkerNumber; i++ {
+		ws = append(ws, sendworker.NewHTTPWorker("log", config.Schema, config.Host, collectorPath, config.Timeout, wrapLogCollect, l))
+	}
+	l.Info("newHTTPLogCollector success")
+	return &LogCollector{
+		logger:        l,
+		in:            make(chan *log_models.Log, config.ChanSize),
+		flushReqs:     flush.NewRequests(),
+		bufferMaxSize: streamMaxBatchBytes, // 64KB
+		offset:        0,                   // do not need
+		flushInterval: 5 * time.Second,
+		tags:          nil, // hostname is sent in LogCollect instead
+		ws:            ws,
+	}
+}
+
+func wrapLogCollect(logs [][]byte) ([]byte, error) {
+	lc := &log_models.LogCollect{
+		Logs:     logs,
+		Hostname: agentless_adapter.GetHostname(),
+	}
+	return lc.Marshal()
+}
+
 func (s *LogCollector) Send(log *log_models.Log) {
 	select {
 	case s.in <- log:
+		selfstats.Log.AddEnqueued(1)
 	default:
-		break
+		selfstats.Log.AddDropped(1)
 	}
 }
 
 func (s *LogCollector) Start() {
+	if s.otlp != nil {
+		s.wg.Add(1)
+		go func() {
+			defer func() {
+				s.wg.Done()
+			}()
+			s.otlpSendLoop()
+		}()
+		return
+	}
 	for _, w := range s.ws {
 		s.wg.Add(1)
 		go func(iw sendworker.SendWorker) {
@@ -131,6 +209,46 @@ func (s *LogCollector) Stop() {
 	s.wg.Wait()
 }
 
+// StopWithContext is like Stop, but gives up waiting for workers when ctx is done, and returns the number of logs
+// left in channel, which are dropped. Logs in batches being sent are not counted
+func (s *LogCollector) StopWithContext(ctx context.Context) (int, error) {
+	close(s.in)
+	if err := flush.Wait(ctx, s.wg.Wait); err != nil {
+		lost := 0
+		s.drainIn(func(item *log_models.Log) {
+			lost++
+		})
+		selfstats.Log.AddDropped(int64(lost))
+		return lost, err
+	}
+	return 0, nil
+}
+
+// Flush sends logs queued in channel and buffered in batches of all workers, and waits for them to be sent until ctx is done.
+// It should be called after Start
+func (s *LogCollector) Flush(ctx context.Context) error {
+	n := len(s.ws)
+	if s.otlp != nil {
+		n = 1
+	}
+	return s.flushReqs.Flush(ctx, n)
+}
+
+// drainIn receives logs queued in channel without blocking
+func (s *LogCollector) drainIn(add func(item *log_models.Log)) {
+	for {
+		select {
+		case item, ok := <-s.in:
+			if !ok {
+				return
+			}
+			add(item)
+		default:
+			return
+		}
+	}
+}
+
 func (s *LogCollector) sendLoop(w sendworker.SendWorker) {
 	defer func() {
 		w.CloseConn()
@@ -154,38 +272,54 @@ func (s *LogCollector) sendLoop(w sendworker.SendWorker) {
 				}
 				return
 			}
-			if item == nil {
-				continue
-			}
-			size := item.Size()
-			sizePrefixData := make([]byte, 4+size)
-			binary.LittleEndian.PutUint32(sizePrefixData[0:4], uint32(size)) // Is's ok to cast a positive int to uint32
-			_, err := item.MarshalTo(sizePrefixData[4:])
-			if err != nil {
-				s.logger.Error("send log marshal err %v", err)
-				continue
+			batchLog = s.appendLog(w, batchLog, item)
+		case b := <-s.flushReqs.C:
+			s.drainIn(func(item *log_models.Log) {
+				batchLog = s.appendLog(w, batchLog, item)
+			})
+			if len(batchLog) > s.offset {
+				w.BatchSend(batchLog, s.tags)
+				batchLog = batchLog[:s.offset]
 			}
+			b.Done()
+		}
+	}
+}
+
+// appendLog appends size-prefixed item to batchLog, and sends batchLog by w if it is full
+func (s *LogCollector) appendLog(w sendworker.SendWorker, batchLog []byte, item *log_models.Log) []byte {
+	if item == nil {
+		return batchLog
+	}
+	size := item.Size()
+	sizePrefixData := make([]byte, 4+size)
+	binary.LittleEndian.PutUint32(sizePrefixData[0:4], uint32(size)) // Is's ok to cast a positive int to uint32
+	_, err := item.MarshalTo(sizePrefixData[4:])
+	if err != nil {
+		s.logger.Error("send log marshal err %v", err)
+		selfstats.Log.AddEncodeError(1)
+		return batchLog
+	}
 
-			s.logger.Debug("send logs %+v, len=%d", item, size)
+	s.logger.Debug("send logs %+v, len=%d", item, size)
 
-			if len(batchLog)+len(sizePrefixData) <= s.bufferMaxSize+s.offset {
-				batchLog = append(batchLog, sizePrefixData...)
+	if len(batchLog)+len(sizePrefixData) <= s.bufferMaxSize+s.offset {
+		batchLog = append(batchLog, sizePrefixData...)
+	} else {
+		if len(sizePrefixData) > s.bufferMaxSize+s.offset { // avoid grow batchLog
+			var tmpBuf []byte
+			if s.offset > 0 {
+				tmpBuf = make([]byte, s.offset, s.offset+len(sizePrefixData)) // preAlloc. very low chance to enter this condition
+				tmpBuf = append(tmpBuf, sizePrefixData...)
 			} else {
-				if len(sizePrefixData) > s.bufferMaxSize+s.offset { // avoid grow batchLog
-					var tmpBuf []byte
-					if s.offset > 0 {
-						tmpBuf = make([]byte, s.offset, s.offset+len(sizePrefixData)) // preAlloc. very low chance to enter this condition
-						tmpBuf = append(tmpBuf, sizePrefixData...)
-					} else {
-						tmpBuf = sizePrefixData
-					}
-					w.BatchSend(tmpBuf, s.tags) // this will lead agent to read truncated data. should discard directly here?
-				} else {
-					w.BatchSend(batchLog, s.tags)
-					batchLog = batchLog[:s.offset]
-					batchLog = append(batchLog, sizePrefixData...)
-				}
+				tmpBuf = sizePrefixData
 			}
+			w.BatchSend(tmpBuf, s.tags) // this will lead agent to read truncated data. should discard directly here?
+		} else {
+			w.BatchSend(batchLog, s.tags)
+			batchLog = batchLog[:s.offset]
+			batchLog = append(batchLog, sizePrefixData...)
 		}
 	}
+	return batchLog
 }
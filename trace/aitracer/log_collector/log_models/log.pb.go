@@ -44,7 +44,7 @@ func (m *Log) Reset()         { *m = Log{} }
 func (m *Log) String() string { return proto.CompactTextString(m) }
 func (*Log) ProtoMessage()    {}
 func (*Log) Descriptor() ([]byte, []int) {
-	return fileDescriptor_log_05c2ae4f3496eb6d, []int{0}
+	return fileDescriptor_log_fc1e91eca875a9b8, []int{0}
 }
 func (m *Log) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -143,8 +143,61 @@ func (m *Log) GetContainerId() string {
 	return ""
 }
 
+type LogCollect struct {
+	Logs     [][]byte `protobuf:"bytes,1,rep,name=logs" json:"logs,omitempty"`
+	Hostname string   `protobuf:"bytes,2,req,name=hostname" json:"hostname"`
+}
+
+func (m *LogCollect) Reset()         { *m = LogCollect{} }
+func (m *LogCollect) String() string { return proto.CompactTextString(m) }
+func (*LogCollect) ProtoMessage()    {}
+func (*LogCollect) Descriptor() ([]byte, []int) {
+	return fileDescriptor_log_fc1e91eca875a9b8, []int{1}
+}
+func (m *LogCollect) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LogCollect) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LogCollect.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *LogCollect) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogCollect.Merge(dst, src)
+}
+func (m *LogCollect) XXX_Size() int {
+	return m.Size()
+}
+func (m *LogCollect) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogCollect.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogCollect proto.InternalMessageInfo
+
+func (m *LogCollect) GetLogs() [][]byte {
+	if m != nil {
+		return m.Logs
+	}
+	return nil
+}
+
+func (m *LogCollect) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Log)(nil), "log_models.Log")
+	proto.RegisterType((*LogCollect)(nil), "log_models.LogCollect")
 }
 func (m *Log) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
@@ -204,6 +257,36 @@ func (m *Log) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *LogCollect) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LogCollect) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for _, b := range m.Logs {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintLog(dAtA, i, uint64(len(b)))
+			i += copy(dAtA[i:], b)
+		}
+	}
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintLog(dAtA, i, uint64(len(m.Hostname)))
+	i += copy(dAtA[i:], m.Hostname)
+	return i, nil
+}
+
 func encodeVarintLog(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -242,6 +325,23 @@ func (m *Log) Size() (n int) {
 	return n
 }
 
+func (m *LogCollect) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Logs) > 0 {
+		for _, b := range m.Logs {
+			l = len(b)
+			n += 1 + l + sovLog(uint64(l))
+		}
+	}
+	l = len(m.Hostname)
+	n += 1 + l + sovLog(uint64(l))
+	return n
+}
+
 func sovLog(x uint64) (n int) {
 	for {
 		n++
@@ -590,6 +690,119 @@ func (m *Log) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *LogCollect) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLog
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LogCollect: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LogCollect: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Logs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Logs = append(m.Logs, make([]byte, postIndex-iNdEx))
+			copy(m.Logs[len(m.Logs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hostname", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLog
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLog
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hostname = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+			hasFields[0] |= uint64(0x00000001)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLog(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthLog
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("hostname")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipLog(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -695,25 +908,27 @@ var (
 	ErrIntOverflowLog   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("log.proto", fileDescriptor_log_05c2ae4f3496eb6d) }
-
-var fileDescriptor_log_05c2ae4f3496eb6d = []byte{
-	// 265 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x4c, 0xcf, 0x31, 0x6e, 0x83, 0x30,
-	0x14, 0xc6, 0x71, 0x0c, 0x55, 0x81, 0x17, 0xba, 0x78, 0xf2, 0x50, 0x39, 0x6e, 0x96, 0x32, 0xf5,
-	0x10, 0xd9, 0x22, 0xa1, 0x0e, 0xbd, 0x00, 0x42, 0xf0, 0x4a, 0x2d, 0x19, 0x1c, 0x61, 0x37, 0xe7,
-	0xe8, 0xb1, 0x32, 0x66, 0xec, 0x50, 0x55, 0x15, 0x5c, 0xa4, 0x32, 0x22, 0xc4, 0xeb, 0xff, 0xfb,
-	0xc9, 0xd6, 0x83, 0x54, 0xe9, 0xf6, 0xe5, 0x38, 0x68, 0xab, 0x29, 0x28, 0xdd, 0x96, 0x9d, 0x6e,
-	0x50, 0x99, 0xdd, 0x4f, 0x08, 0x51, 0xa1, 0x5b, 0xca, 0x21, 0xee, 0xd0, 0x98, 0xaa, 0x45, 0x46,
-	0x44, 0x98, 0x67, 0xfb, 0xbb, 0xf3, 0xef, 0x36, 0x78, 0xbb, 0x46, 0xba, 0x83, 0xd4, 0xca, 0x0e,
-	0x8d, 0xad, 0xba, 0x23, 0x0b, 0x45, 0x98, 0x47, 0x8b, 0xb8, 0x65, 0x2a, 0x20, 0xf9, 0xd0, 0xc6,
-	0xf6, 0x55, 0x87, 0x2c, 0x12, 0x61, 0x9e, 0x2e, 0x64, 0xad, 0xf4, 0x09, 0xd2, 0x77, 0xa9, 0xb0,
-	0x9c, 0x49, 0x2c, 0xc8, 0x8d, 0xb8, 0xfc, 0xea, 0x13, 0x25, 0x7b, 0x64, 0x89, 0x20, 0xeb, 0x47,
-	0x33, 0x29, 0x64, 0x3f, 0x13, 0x77, 0x81, 0xc2, 0x13, 0x2a, 0x96, 0xfa, 0xaf, 0x28, 0xdd, 0x16,
-	0xae, 0xd2, 0x2d, 0x24, 0x76, 0xa8, 0x6a, 0x2c, 0x65, 0xc3, 0xc0, 0x13, 0xf1, 0x5c, 0x0f, 0x8d,
-	0xbb, 0xd7, 0xe0, 0x70, 0x92, 0x35, 0xb2, 0x8d, 0xbf, 0x2f, 0x91, 0x3e, 0xc2, 0xbd, 0xd1, 0x9f,
-	0x43, 0x8d, 0x2c, 0xf3, 0xe6, 0xa5, 0xd1, 0x67, 0xc8, 0x6a, 0xdd, 0xdb, 0x4a, 0xf6, 0x38, 0xb8,
-	0x2f, 0x1e, 0x3c, 0xb3, 0x59, 0x97, 0x43, 0xb3, 0x67, 0xe7, 0x91, 0x93, 0xcb, 0xc8, 0xc9, 0xdf,
-	0xc8, 0xc9, 0xd7, 0xc4, 0x83, 0xcb, 0xc4, 0x83, 0xef, 0x89, 0x07, 0xff, 0x01, 0x00, 0x00, 0xff,
-	0xff, 0x2b, 0x7f, 0x05, 0x44, 0x90, 0x01, 0x00, 0x00,
+func init() { proto.RegisterFile("log.proto", fileDescriptor_log_fc1e91eca875a9b8) }
+
+var fileDescriptor_log_fc1e91eca875a9b8 = []byte{
+	// 292 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x90, 0x31, 0x4e, 0xc3, 0x30,
+	0x14, 0x86, 0xe3, 0xa4, 0xa2, 0xcd, 0x6b, 0x58, 0x3c, 0x79, 0x40, 0xae, 0xe9, 0x42, 0x26, 0x0e,
+	0x51, 0xa6, 0x4a, 0x11, 0x43, 0x2f, 0x50, 0x45, 0xc9, 0xc3, 0x58, 0x72, 0xf2, 0xaa, 0xd8, 0xf4,
+	0x1c, 0x1c, 0xab, 0x63, 0x47, 0x06, 0x84, 0x50, 0x7b, 0x11, 0x94, 0x28, 0xb4, 0x46, 0x62, 0xb3,
+	0xbe, 0xff, 0x93, 0x9f, 0xfe, 0x1f, 0x52, 0x4b, 0xfa, 0x71, 0xd7, 0x91, 0x27, 0x0e, 0x96, 0xf4,
+	0xb6, 0xa1, 0x1a, 0xad, 0x5b, 0x7e, 0xc6, 0x90, 0x14, 0xa4, 0xb9, 0x84, 0x69, 0x83, 0xce, 0x95,
+	0x1a, 0x05, 0x53, 0x71, 0x9e, 0xad, 0x26, 0x87, 0xaf, 0x45, 0xb4, 0xf9, 0x85, 0x7c, 0x09, 0xa9,
+	0x37, 0x0d, 0x3a, 0x5f, 0x36, 0x3b, 0x11, 0xab, 0x38, 0x4f, 0x46, 0xe3, 0x8a, 0xb9, 0x82, 0xd9,
+	0x2b, 0x39, 0xdf, 0x96, 0x0d, 0x8a, 0x44, 0xc5, 0x79, 0x3a, 0x2a, 0x17, 0xca, 0xef, 0x21, 0x7d,
+	0x31, 0x16, 0xb7, 0x83, 0x32, 0x55, 0xec, 0xaa, 0xf4, 0xf8, 0x39, 0x54, 0xac, 0x69, 0x51, 0xcc,
+	0x14, 0xcb, 0x93, 0x50, 0x29, 0x4c, 0x3b, 0x28, 0x7d, 0x03, 0x8b, 0x7b, 0xb4, 0x22, 0x0d, 0x7f,
+	0xb1, 0xa4, 0x8b, 0x9e, 0xf2, 0x05, 0xcc, 0x7c, 0x57, 0x56, 0xb8, 0x35, 0xb5, 0x80, 0xc0, 0x98,
+	0x0e, 0x74, 0x5d, 0xf7, 0x7d, 0x1d, 0x76, 0x7b, 0x53, 0xa1, 0x98, 0x87, 0xf9, 0x08, 0xf9, 0x1d,
+	0xdc, 0x38, 0x7a, 0xeb, 0x2a, 0x14, 0x59, 0x10, 0x8f, 0x8c, 0x3f, 0x40, 0x56, 0x51, 0xeb, 0x4b,
+	0xd3, 0x62, 0xd7, 0x9f, 0xb8, 0x0d, 0x9c, 0xf9, 0x25, 0x59, 0xd7, 0xcb, 0x15, 0x40, 0x41, 0xfa,
+	0x89, 0xac, 0xc5, 0xca, 0x73, 0x0e, 0x13, 0x4b, 0xda, 0x09, 0xa6, 0x92, 0x3c, 0xdb, 0x0c, 0xef,
+	0x3f, 0xa3, 0xc5, 0xff, 0x8d, 0xb6, 0x12, 0x87, 0x93, 0x64, 0xc7, 0x93, 0x64, 0xdf, 0x27, 0xc9,
+	0xde, 0xcf, 0x32, 0x3a, 0x9e, 0x65, 0xf4, 0x71, 0x96, 0xd1, 0xcf, 0x00, 0x22, 0xc0, 0xec, 0x42,
+	0xd4, 0x01, 0x00, 0x00,
 }
@@ -0,0 +1,135 @@
+package log_collector
+
+import (
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/flush"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/otlphttp"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/log_collector/log_models"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/service_register/register_utils"
+	collectorlogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+)
+
+const (
+	otlpMaxBatchLogs  = 512
+	otlpFlushInterval = 5 * time.Second
+	otlpScopeName     = "apminsight-server-sdk-go"
+)
+
+var otlpSeverity = map[string]logspb.SeverityNumber{
+	"trace":  logspb.SeverityNumber_SEVERITY_NUMBER_TRACE,
+	"debug":  logspb.SeverityNumber_SEVERITY_NUMBER_DEBUG,
+	"info":   logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+	"notice": logspb.SeverityNumber_SEVERITY_NUMBER_INFO2,
+	"warn":   logspb.SeverityNumber_SEVERITY_NUMBER_WARN,
+	"error":  logspb.SeverityNumber_SEVERITY_NUMBER_ERROR,
+	"fatal":  logspb.SeverityNumber_SEVERITY_NUMBER_FATAL,
+}
+
+func newOTLPLogCollector(config LogCollectorConfig) *LogCollector {
+	var l logger.Logger
+	if config.Debug {
+		l = &logger.DebugLogger{}
+	} else {
+		l = &logger.NoopLogger{}
+	}
+	l.Info("newOTLPLogCollector success")
+	return &LogCollector{
+		logger:        l,
+		in:            make(chan *log_models.Log, config.ChanSize),
+		flushReqs:     flush.NewRequests(),
+		flushInterval: otlpFlushInterval,
+		otlp:          otlphttp.NewClient(config.OTLP),
+	}
+}
+
+func (s *LogCollector) otlpSendLoop() {
+	batch := make([]*log_models.Log, 0, otlpMaxBatchLogs)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.otlp.Export(otlphttp.LogsPath, toOTLPLogs(batch)); err != nil {
+			s.logger.Error("export otlp logs err %v", err)
+			selfstats.Log.AddConnError(1)
+			selfstats.Log.AddDropped(int64(len(batch)))
+		} else {
+			selfstats.Log.AddSent(int64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+
+	add := func(item *log_models.Log) {
+		if item == nil {
+			return
+		}
+		batch = append(batch, item)
+		if len(batch) >= otlpMaxBatchLogs {
+			flush()
+		}
+	}
+
+	tc := time.NewTicker(s.flushInterval)
+	defer func() {
+		tc.Stop()
+	}()
+	for {
+		select {
+		case <-tc.C:
+			flush()
+		case item, ok := <-s.in:
+			if !ok {
+				flush()
+				return
+			}
+			add(item)
+		case b := <-s.flushReqs.C:
+			s.drainIn(add)
+			flush()
+			b.Done()
+		}
+	}
+}
+
+// toOTLPLogs groups logs by service
+func toOTLPLogs(logs []*log_models.Log) *collectorlogspb.ExportLogsServiceRequest {
+	req := &collectorlogspb.ExportLogsServiceRequest{}
+	instanceID := register_utils.GetInstanceID()
+	resourceIndex := make(map[string]*logspb.ScopeLogs)
+	for _, log := range logs {
+		scopeLogs, ok := resourceIndex[log.Service]
+		if !ok {
+			scopeLogs = &logspb.ScopeLogs{Scope: &commonpb.InstrumentationScope{Name: otlpScopeName}}
+			resourceIndex[log.Service] = scopeLogs
+			req.ResourceLogs = append(req.ResourceLogs, &logspb.ResourceLogs{
+				Resource: otlphttp.Resource(map[string]string{
+					"service.name":        log.Service,
+					"service.instance.id": instanceID,
+					"container.id":        log.ContainerId,
+					"host.name":           log.Hostname,
+				}),
+				ScopeLogs: []*logspb.ScopeLogs{scopeLogs},
+			})
+		}
+		record := &logspb.LogRecord{
+			TimeUnixNano:   uint64(log.Timestamp) * 1e6,
+			SeverityNumber: otlpSeverity[log.LogLevel],
+			SeverityText:   log.LogLevel,
+			Body:           &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: string(log.Message)}},
+			Attributes: otlphttp.StringAttributes(map[string]string{
+				"code.filepath": log.FileName,
+				"log.source":    log.Source,
+			}),
+			TraceId: otlphttp.TraceID(log.TraceId),
+		}
+		if log.FileLine > 0 {
+			record.Attributes = append(record.Attributes, otlphttp.Int("code.lineno", log.FileLine))
+		}
+		scopeLogs.LogRecords = append(scopeLogs.LogRecords, record)
+	}
+	return req
+}
@@ -0,0 +1,49 @@
+package aitracer
+
+import (
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/otlphttp"
+	"github.com/volcengine/apminsight-server-sdk-go/metrics"
+)
+
+// OTLPConfig configures OTLP/HTTP export. If Endpoint is set, traces, logs and metrics are sent to
+// Endpoint+/v1/traces, /v1/logs and /v1/metrics instead of server-agent.
+// Settings fetching and service register still depend on server-agent.
+type OTLPConfig struct {
+	Endpoint string // base url of collector, like http://localhost:4318
+	Headers  map[string]string
+
+	DisableGzip bool
+	Timeout     time.Duration // timeout of each request, default 10s
+
+	RetryCount      int           // default 3, negative value disables retry
+	BackoffInterval time.Duration // initial backoff, doubled after each retry. default 1s
+}
+
+func (c OTLPConfig) toOTLPHTTPConfig() otlphttp.Config {
+	return otlphttp.Config{
+		Endpoint:        c.Endpoint,
+		Headers:         c.Headers,
+		DisableGzip:     c.DisableGzip,
+		Timeout:         c.Timeout,
+		RetryCount:      c.RetryCount,
+		BackoffInterval: c.BackoffInterval,
+	}
+}
+
+func (c OTLPConfig) toMetricsOTLPConfig(serviceType, service, instanceID string) metrics.OTLPConfig {
+	return metrics.OTLPConfig{
+		Endpoint:        c.Endpoint,
+		Headers:         c.Headers,
+		DisableGzip:     c.DisableGzip,
+		Timeout:         c.Timeout,
+		RetryCount:      c.RetryCount,
+		BackoffInterval: c.BackoffInterval,
+		ResourceAttributes: map[string]string{
+			"service.name":            service,
+			"service.instance.id":     instanceID,
+			"apminsight.service_type": serviceType,
+		},
+	}
+}
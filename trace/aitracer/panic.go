@@ -0,0 +1,70 @@
+package aitracer
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	panicSpanName     = "panic"
+	crashFlushTimeout = 3 * time.Second
+)
+
+// CapturePanics records panic of current goroutine, sends traces, logs and metrics, and then re-panics.
+// It should be called directly by defer at the top of goroutine, and recover() should be called directly in it:
+//
+//	go func() {
+//		defer aitracer.CapturePanics(ctx)
+//		...
+//	}()
+//
+// panic is recorded as ErrorKindPanic on span of ctx, and all unfinished spans of the trace are finished with error status.
+// If span of ctx is finished, panic is recorded on a new child span. If there is no span in ctx,
+// panic is recorded as ErrorKindUncaughtException on a new span of global tracer.
+// Traces, logs and metrics are flushed synchronously in at most 3 seconds before re-panic, so that they are not lost by crash.
+func CapturePanics(ctx context.Context) {
+	err := recover()
+	if err == nil {
+		return
+	}
+	capturePanic(ctx, err)
+	panic(err)
+}
+
+// SafeGo runs fn in a new goroutine with panic captured by CapturePanics
+func SafeGo(ctx context.Context, fn func(ctx context.Context)) {
+	go func() {
+		defer CapturePanics(ctx)
+		fn(ctx)
+	}()
+}
+
+func capturePanic(ctx context.Context, err interface{}) {
+	s, _ := GetSpanFromContext(ctx).(*span)
+	var t *tracer
+	if s != nil {
+		t = s.spanContext.traceContext.tracer
+	} else {
+		t, _ = GlobalTracer().(*tracer)
+	}
+	if t == nil {
+		return
+	}
+
+	errorKind := ErrorKindPanic
+	if s == nil {
+		s, _ = t.StartSpan(panicSpanName).(*span)
+		errorKind = ErrorKindUncaughtException
+	} else if s.isFinished() {
+		s, _ = t.StartSpan(panicSpanName, ChildOf(s.Context())).(*span)
+	}
+	if s == nil {
+		return
+	}
+	s.recordPanic(errorKind, err)
+	s.spanContext.traceContext.finishAll()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), crashFlushTimeout)
+	defer cancel()
+	_ = t.Flush(flushCtx)
+}
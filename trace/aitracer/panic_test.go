@@ -0,0 +1,47 @@
+package aitracer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
+)
+
+func TestCapturePanics(t *testing.T) {
+	tr := NewTracer(Http, "svc").(*tracer)
+	tr.traceSenders, tr.logCollector, tr.metricsClient, tr.metricsClients = nil, nil, nil, nil // traces are read from channel directly
+	SetGlobalTracer(tr)
+	defer SetGlobalTracer(nil)
+
+	capture := func(ctx context.Context) (recovered interface{}) {
+		defer func() {
+			recovered = recover()
+		}()
+		defer CapturePanics(ctx)
+		panic("boom")
+	}
+
+	// panic is recorded on active span, and unfinished spans of the trace are finished
+	server, ctx := tr.StartServerSpanFromContext(context.Background(), "server")
+	_, ctx = tr.StartSpanFromContext(ctx, "worker")
+	assert.Equal(t, "boom", capture(ctx))
+	trace := <-tr.traceChan
+	assert.Equal(t, 2, len(trace.Spans))
+	for _, s := range trace.Spans {
+		assert.Equal(t, int64(StatusCodeError), s.Status)
+	}
+	worker := trace.Spans[1]
+	assert.Equal(t, "worker", worker.OperationName)
+	assert.Equal(t, 1, len(worker.ErrorInfoList))
+	assert.Equal(t, trace_models.ErrorType_Panic, worker.ErrorInfoList[0].ErrorKind)
+	assert.Equal(t, "boom", worker.ErrorInfoList[0].ErrorMessage)
+	server.Finish() // no-op
+
+	// panic without span is recorded on a new span
+	assert.Equal(t, "boom", capture(context.Background()))
+	trace = <-tr.traceChan
+	assert.Equal(t, 1, len(trace.Spans))
+	assert.Equal(t, panicSpanName, trace.Spans[0].OperationName)
+	assert.Equal(t, trace_models.ErrorType_UncaughtException, trace.Spans[0].ErrorInfoList[0].ErrorKind)
+}
@@ -0,0 +1,28 @@
+package aitracer
+
+import (
+	"context"
+	"runtime/pprof"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
+)
+
+// setPprofLabels sets trace_id, span_id and resource of s as pprof labels of current goroutine, which are kept in
+// cpu and goroutine profiles. Labels in parent are kept, and labels of goroutine are restored to parent when s finished,
+// so s should be finished in the same goroutine. ctx with labels is returned, goroutines started by pprof.Do(ctx, ...) inherit them.
+func (s *span) setPprofLabels(parent, ctx context.Context) context.Context {
+	ctx = pprof.WithLabels(ctx, pprof.Labels(
+		internal.PprofLabelTraceID, s.spanContext.traceContext.traceID,
+		internal.PprofLabelSpanID, s.spanContext.spanID,
+		internal.PprofLabelResource, s.spanContext.traceContext.resource,
+	))
+	pprof.SetGoroutineLabels(ctx)
+	s.pprofParent = parent
+	return ctx
+}
+
+func (s *span) restorePprofLabels() {
+	if s.pprofParent != nil {
+		pprof.SetGoroutineLabels(s.pprofParent)
+	}
+}
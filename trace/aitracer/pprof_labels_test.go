@@ -0,0 +1,50 @@
+package aitracer
+
+import (
+	"bytes"
+	"context"
+	"runtime/pprof"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
+)
+
+func TestPprofLabels(t *testing.T) {
+	tr := NewTracer(Http, "svc", WithPprofLabels(true)).(*tracer)
+	parent := pprof.WithLabels(context.Background(), pprof.Labels("k", "v"))
+	s, ctx := tr.StartServerSpanFromContext(parent, "server", ServerResourceAs("/checkout"))
+
+	traceID, _ := pprof.Label(ctx, internal.PprofLabelTraceID)
+	assert.Equal(t, s.Context().TraceID(), traceID)
+	spanID, _ := pprof.Label(ctx, internal.PprofLabelSpanID)
+	assert.Equal(t, s.Context().SpanID(), spanID)
+	resource, _ := pprof.Label(ctx, internal.PprofLabelResource)
+	assert.Equal(t, "/checkout", resource)
+	v, _ := pprof.Label(ctx, "k") // labels of parent are kept
+	assert.Equal(t, "v", v)
+	assert.Equal(t, s.Context().TraceID(), GetSpanFromContext(ctx).Context().TraceID())
+
+	s.Finish()
+}
+
+func TestPprofLabelsRestore(t *testing.T) {
+	tr := NewTracer(Http, "svc", WithPprofLabels(true)).(*tracer)
+	// labels of goroutines are only readable from goroutine profile
+	profiled := func(traceID string) bool {
+		var buf bytes.Buffer
+		_ = pprof.Lookup("goroutine").WriteTo(&buf, 1)
+		return strings.Contains(buf.String(), traceID)
+	}
+	pprof.Do(context.Background(), pprof.Labels("outer", "v"), func(ctx context.Context) {
+		s, _ := tr.StartServerSpanFromContext(ctx, "server")
+		assert.True(t, profiled(s.Context().TraceID()))
+		s.Finish()
+		assert.False(t, profiled(s.Context().TraceID())) // labels of pprof.Do are restored
+
+		s = tr.StartServerSpan("server") // labels are not set without ctx
+		assert.False(t, profiled(s.Context().TraceID()))
+		s.Finish()
+	})
+}
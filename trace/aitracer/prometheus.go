@@ -0,0 +1,17 @@
+package aitracer
+
+import (
+	"net/http"
+
+	"github.com/volcengine/apminsight-server-sdk-go/metrics"
+)
+
+// PrometheusHandler returns an http.Handler which exposes span, runtime and self stats metrics of tracer
+// in Prometheus text format. tracer should be created with WithPrometheus(true)
+func PrometheusHandler(tr Tracer) http.Handler {
+	t, _ := tr.(*tracer)
+	if t == nil {
+		return metrics.PrometheusHandler()
+	}
+	return metrics.PrometheusHandler(t.metricsClients...)
+}
@@ -16,6 +16,9 @@ type BuiltinFormat byte
 const (
 	HTTPHeaders BuiltinFormat = iota
 	Binary
+	W3CTraceContext // carrier is HTTPHeadersCarrier. see https://www.w3.org/TR/trace-context/
+	B3              // carrier is HTTPHeadersCarrier. multi headers by default, use WithPropagator(B3, &B3Injector{SingleHeader: true}, &B3Extractor{}) for single header
+	Composite       // carrier is HTTPHeadersCarrier. Inject writes all formats of CompositeFormats, Extract tries them in order
 )
 
 type HTTPHeadersCarrier http.Header
@@ -103,6 +106,11 @@ type HTTPHeaderExtractSpanContext struct {
 	clientSampled  bool // currently only spanContext from HTTP Header contains clientSampled
 	sampleFlags    SampleFlags
 	baggage        map[string]string
+	traceState     internal.TraceState // tracestate of W3C trace context
+}
+
+func (sc *HTTPHeaderExtractSpanContext) w3cTraceState() internal.TraceState {
+	return sc.traceState
 }
 
 func (sc *HTTPHeaderExtractSpanContext) TraceID() string {
@@ -0,0 +1,151 @@
+package aitracer
+
+import (
+	"strings"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/internal"
+)
+
+const (
+	b3SingleHeader       = "b3"
+	b3TraceIDHeader      = "x-b3-traceid"
+	b3SpanIDHeader       = "x-b3-spanid"
+	b3ParentSpanIDHeader = "x-b3-parentspanid"
+	b3SampledHeader      = "x-b3-sampled"
+	b3FlagsHeader        = "x-b3-flags"
+	b3FullSpanIDHeader   = "x-apminsight-spanid" // full spanId, set when spanId is longer than 16 characters
+
+	b3SampledAccept = "1"
+	b3SampledDeny   = "0"
+	b3SampledDebug  = "d"
+
+	b3SpanIDLength    = 16
+	b3TraceID64Length = 16
+	b3TraceIDPadding  = "0000000000000000"
+)
+
+// B3Injector writes B3 headers, see https://github.com/openzipkin/b3-propagation.
+// multi headers (X-B3-TraceId, X-B3-SpanId, X-B3-Sampled) are written by default, set SingleHeader to write b3 header instead.
+// traceId generated by aitracer is 128-bit and written as is. spanId is 32 characters, so only the last 16 characters are written
+// in B3 headers, and the full spanId is written in X-Apminsight-SpanId header, which is ignored by other B3 implementations.
+type B3Injector struct {
+	SingleHeader bool
+}
+
+var _ Injector = &B3Injector{}
+
+func (injector *B3Injector) Inject(sc SpanContext, carrier interface{}) error {
+	c, ok := carrier.(HTTPHeadersCarrier)
+	if !ok {
+		return ErrInvalidCarrier
+	}
+	traceID := strings.ToLower(sc.TraceID())
+	fullSpanID := strings.ToLower(sc.SpanID())
+	spanID := fullSpanID
+	if len(spanID) > b3SpanIDLength {
+		spanID = spanID[len(spanID)-b3SpanIDLength:]
+	}
+	if !internal.IsValidTraceID(traceID) || !internal.IsValidSpanID(spanID) {
+		return ErrInvalidSpanContext
+	}
+	if fullSpanID != spanID {
+		c.Set(b3FullSpanIDHeader, fullSpanID)
+	}
+
+	var sampled string
+	strategy, _ := sc.Sample()
+	switch strategy {
+	case SampleStrategySampled:
+		sampled = b3SampledAccept
+	case SampleStrategyNotSampled:
+		sampled = b3SampledDeny
+	}
+
+	if injector.SingleHeader {
+		b3 := traceID + "-" + spanID
+		if sampled != "" {
+			b3 += "-" + sampled
+		}
+		c.Set(b3SingleHeader, b3)
+		return nil
+	}
+	c.Set(b3TraceIDHeader, traceID)
+	c.Set(b3SpanIDHeader, spanID)
+	if sampled != "" {
+		c.Set(b3SampledHeader, sampled)
+	}
+	return nil
+}
+
+// B3Extractor reads both b3 single header and multi headers. b3 single header takes precedence.
+// 64-bit traceId is left-padded with zeros to 128-bit. full spanId in X-Apminsight-SpanId header is used if it ends with B3 spanId.
+// sampling decision of B3 is treated as server side sample result of upstream, debug is treated as sampled.
+type B3Extractor struct{}
+
+var _ Extractor = &B3Extractor{}
+
+func (extractor *B3Extractor) Extract(carrier interface{}) (SpanContext, error) {
+	c, ok := carrier.(HTTPHeadersCarrier)
+	if !ok {
+		return nil, ErrInvalidCarrier
+	}
+	var single, traceID, spanID, sampled, flags, fullSpanID string
+	_ = c.ForeachKey(func(key, val string) error {
+		switch strings.ToLower(key) {
+		case b3SingleHeader:
+			single = val
+		case b3TraceIDHeader:
+			traceID = val
+		case b3SpanIDHeader:
+			spanID = val
+		case b3SampledHeader:
+			sampled = val
+		case b3FlagsHeader:
+			flags = val
+		case b3FullSpanIDHeader:
+			fullSpanID = val
+		}
+		return nil
+	})
+
+	if single != "" {
+		// {TraceId}-{SpanId}-{SamplingState}-{ParentSpanId}, the last two fields are optional.
+		// single sampling state like "0" carries no context, and is ignored
+		fields := strings.Split(strings.TrimSpace(single), "-")
+		if len(fields) < 2 {
+			return nil, nil
+		}
+		traceID, spanID, sampled = fields[0], fields[1], ""
+		if len(fields) > 2 {
+			sampled = fields[2]
+		}
+	} else if flags == "1" {
+		sampled = b3SampledDebug
+	}
+
+	traceID = strings.ToLower(strings.TrimSpace(traceID))
+	spanID = strings.ToLower(strings.TrimSpace(spanID))
+	if len(traceID) == b3TraceID64Length {
+		traceID = b3TraceIDPadding + traceID
+	}
+	if !internal.IsValidTraceID(traceID) || !internal.IsValidSpanID(spanID) {
+		return nil, nil
+	}
+	if fullSpanID = strings.ToLower(strings.TrimSpace(fullSpanID)); len(fullSpanID) > b3SpanIDLength && strings.HasSuffix(fullSpanID, spanID) {
+		spanID = fullSpanID
+	}
+
+	ctx := HTTPHeaderExtractSpanContext{
+		traceID:     traceID,
+		spanID:      spanID,
+		sampleFlags: SampleFlagsUnknown, // determined by sampleStrategy
+	}
+	switch strings.ToLower(strings.TrimSpace(sampled)) {
+	case b3SampledAccept, b3SampledDebug, "true":
+		ctx.sampleStrategy = SampleStrategySampled
+		ctx.sampleWeight = 1
+	case b3SampledDeny, "false":
+		ctx.sampleStrategy = SampleStrategyNotSampled
+	}
+	return &ctx, nil
+}
@@ -0,0 +1,70 @@
+package aitracer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestB3Propagation(t *testing.T) {
+	sc := &HTTPHeaderExtractSpanContext{
+		traceID:        "2023010112000000a1b2c3d4e5f60718",
+		spanID:         "20230101120000001122334455667788",
+		sampleStrategy: SampleStrategySampled,
+	}
+
+	carrier := HTTPHeadersCarrier(http.Header{})
+	assert.Nil(t, (&B3Injector{}).Inject(sc, carrier))
+	assert.Equal(t, "2023010112000000a1b2c3d4e5f60718", http.Header(carrier).Get(b3TraceIDHeader))
+	assert.Equal(t, "1122334455667788", http.Header(carrier).Get(b3SpanIDHeader))
+	assert.Equal(t, "1", http.Header(carrier).Get(b3SampledHeader))
+	assert.Equal(t, sc.spanID, http.Header(carrier).Get(b3FullSpanIDHeader))
+
+	carrier = HTTPHeadersCarrier(http.Header{})
+	assert.Nil(t, (&B3Injector{SingleHeader: true}).Inject(sc, carrier))
+	assert.Equal(t, "2023010112000000a1b2c3d4e5f60718-1122334455667788-1", http.Header(carrier).Get(b3SingleHeader))
+	extracted, err := (&B3Extractor{}).Extract(carrier)
+	assert.Nil(t, err)
+	assert.Equal(t, sc.traceID, extracted.TraceID())
+	assert.Equal(t, sc.spanID, extracted.SpanID())
+
+	// full spanId is dropped by B3 only hops, and spanId is lossy then
+	http.Header(carrier).Del(b3FullSpanIDHeader)
+	extracted, _ = (&B3Extractor{}).Extract(carrier)
+	assert.Equal(t, "1122334455667788", extracted.SpanID())
+	http.Header(carrier).Set(b3FullSpanIDHeader, "20230101120000009999999999999999")
+	extracted, _ = (&B3Extractor{}).Extract(carrier)
+	assert.Equal(t, "1122334455667788", extracted.SpanID())
+	strategy, weight := extracted.Sample()
+	assert.Equal(t, SampleStrategySampled, strategy)
+	assert.Equal(t, 1, weight)
+
+	// 64-bit traceId and deny
+	carrier = HTTPHeadersCarrier(http.Header{})
+	carrier.Set("X-B3-TraceId", "a3ce929d0e0e4736")
+	carrier.Set("X-B3-SpanId", "00f067aa0ba902b7")
+	carrier.Set("X-B3-Sampled", "0")
+	extracted, _ = (&B3Extractor{}).Extract(carrier)
+	assert.Equal(t, "0000000000000000a3ce929d0e0e4736", extracted.TraceID())
+	strategy, _ = extracted.Sample()
+	assert.Equal(t, SampleStrategyNotSampled, strategy)
+
+	// debug flag
+	carrier.Set("X-B3-Flags", "1")
+	http.Header(carrier).Del("X-B3-Sampled")
+	extracted, _ = (&B3Extractor{}).Extract(carrier)
+	strategy, _ = extracted.Sample()
+	assert.Equal(t, SampleStrategySampled, strategy)
+
+	// deferred decision
+	carrier = HTTPHeadersCarrier(http.Header{})
+	carrier.Set("b3", "a3ce929d0e0e4736-00f067aa0ba902b7")
+	extracted, _ = (&B3Extractor{}).Extract(carrier)
+	strategy, _ = extracted.Sample()
+	assert.Equal(t, SampleStrategyUnknown, strategy)
+
+	carrier.Set("b3", "0")
+	extracted, _ = (&B3Extractor{}).Extract(carrier)
+	assert.Nil(t, extracted)
+}
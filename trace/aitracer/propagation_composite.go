@@ -0,0 +1,53 @@
+package aitracer
+
+// compositePropagator injects all configured formats, and extracts with configured formats in priority order.
+// The first format that extracts a SpanContext wins.
+type compositePropagator struct {
+	injectors  []Injector
+	extractors []Extractor
+}
+
+var _ Injector = &compositePropagator{}
+var _ Extractor = &compositePropagator{}
+
+func newCompositePropagator(formats []interface{}, injects map[interface{}]Injector, extractors map[interface{}]Extractor) *compositePropagator {
+	p := &compositePropagator{}
+	for _, format := range formats {
+		if injector, ok := injects[format]; ok && injector != nil {
+			p.injectors = append(p.injectors, injector)
+		}
+		if extractor, ok := extractors[format]; ok && extractor != nil {
+			p.extractors = append(p.extractors, extractor)
+		}
+	}
+	return p
+}
+
+// Inject writes all formats and returns the first error
+func (p *compositePropagator) Inject(sc SpanContext, carrier interface{}) error {
+	var firstErr error
+	for _, injector := range p.injectors {
+		if err := injector.Inject(sc, carrier); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Extract returns the first SpanContext extracted. If no SpanContext is found, the first error is returned
+func (p *compositePropagator) Extract(carrier interface{}) (SpanContext, error) {
+	var firstErr error
+	for _, extractor := range p.extractors {
+		sc, err := extractor.Extract(carrier)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if sc != nil {
+			return sc, nil
+		}
+	}
+	return nil, firstErr
+}
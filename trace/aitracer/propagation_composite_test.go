@@ -0,0 +1,73 @@
+package aitracer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositePropagator(t *testing.T) {
+	config := newDefaultTracerConfig()
+	injects := map[interface{}]Injector{}
+	extractors := map[interface{}]Extractor{}
+	for _, p := range config.PropagatorConfigs {
+		injects[p.Format] = p.Injector
+		extractors[p.Format] = p.Extractor
+	}
+	composite := newCompositePropagator(config.CompositeFormats, injects, extractors)
+
+	sc := &HTTPHeaderExtractSpanContext{
+		traceID:        "2023010112000000a1b2c3d4e5f60718",
+		spanID:         "20230101120000001122334455667788",
+		sampleStrategy: SampleStrategySampled,
+		sampleWeight:   1,
+		sampleFlags:    SampleFlagsServerSampled,
+	}
+	header := http.Header{}
+	assert.Nil(t, composite.Inject(sc, HTTPHeadersCarrier(header)))
+	assert.NotEmpty(t, header.Get(defaultTraceIDHeader))
+	assert.NotEmpty(t, header.Get(w3cTraceparentHeader))
+	assert.NotEmpty(t, header.Get(b3TraceIDHeader))
+
+	// HTTPHeaders has the highest priority
+	extracted, err := composite.Extract(HTTPHeadersCarrier(header))
+	assert.Nil(t, err)
+	assert.Equal(t, sc.spanID, extracted.SpanID())
+
+	// fallback to b3
+	header = http.Header{}
+	header.Set("b3", "a3ce929d0e0e4736-00f067aa0ba902b7-1")
+	extracted, err = composite.Extract(HTTPHeadersCarrier(header))
+	assert.Nil(t, err)
+	assert.Equal(t, "00f067aa0ba902b7", extracted.SpanID())
+
+	extracted, err = composite.Extract(HTTPHeadersCarrier(http.Header{}))
+	assert.Nil(t, err)
+	assert.Nil(t, extracted)
+
+	_, err = composite.Extract("invalid")
+	assert.Equal(t, ErrInvalidCarrier, err)
+}
+
+type fixedExtractor struct {
+	sc SpanContext
+}
+
+func (e *fixedExtractor) Extract(interface{}) (SpanContext, error) {
+	return e.sc, nil
+}
+
+func TestCompositePropagatorConfigured(t *testing.T) {
+	sc := &HTTPHeaderExtractSpanContext{traceID: "2023010112000000a1b2c3d4e5f60718", spanID: "1122334455667788"}
+	tr := NewTracer(Http, "svc", WithPropagator(Composite, &W3CTraceContextInjector{}, &fixedExtractor{sc: sc}))
+
+	extracted, err := tr.Extract(Composite, HTTPHeadersCarrier(http.Header{}))
+	assert.Nil(t, err)
+	assert.Equal(t, sc, extracted)
+
+	header := http.Header{}
+	assert.Nil(t, tr.Inject(sc, Composite, HTTPHeadersCarrier(header)))
+	assert.NotEmpty(t, header.Get(w3cTraceparentHeader))
+	assert.Empty(t, header.Get(defaultTraceIDHeader))
+}
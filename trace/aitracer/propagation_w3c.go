@@ -0,0 +1,197 @@
+package aitracer
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/internal"
+)
+
+const (
+	w3cTraceparentHeader = "traceparent"
+	w3cTracestateHeader  = "tracestate"
+
+	w3cVersion      = 0
+	w3cFlagsSampled = 0x01
+	w3cSpanIDLength = 16
+
+	// w3cStateVendorKey is tracestate member of aitracer, whose value is like s:<spanId>;w:<weight>.
+	// s is full spanId, set when spanId is longer than 16 characters. w is server side weight
+	w3cStateVendorKey      = "apminsight"
+	w3cStateFieldDelimiter = ";"
+	w3cStateFieldSeparator = ":"
+	w3cStateSpanIDField    = "s"
+	w3cStateWeightField    = "w"
+)
+
+// w3cTraceStateCarrier is implemented by span contexts which keep tracestate of upstream,
+// so that members of other vendors are propagated to downstream
+type w3cTraceStateCarrier interface {
+	w3cTraceState() internal.TraceState
+}
+
+// isOwnTraceStateKey returns true for members written by W3CTraceContextInjector
+func isOwnTraceStateKey(key string) bool {
+	switch key {
+	case w3cStateVendorKey, internal.KeyAppID, internal.KeyOrigin:
+		return true
+	}
+	return false
+}
+
+func formatW3CVendorState(spanID string, weight int) string {
+	fields := make([]string, 0, 2)
+	if spanID != "" {
+		fields = append(fields, w3cStateSpanIDField+w3cStateFieldSeparator+spanID)
+	}
+	if weight > 0 {
+		fields = append(fields, w3cStateWeightField+w3cStateFieldSeparator+strconv.Itoa(weight))
+	}
+	return strings.Join(fields, w3cStateFieldDelimiter)
+}
+
+func parseW3CVendorState(value string) (spanID string, weight int) {
+	for _, field := range strings.Split(value, w3cStateFieldDelimiter) {
+		kv := strings.SplitN(field, w3cStateFieldSeparator, 2)
+		if len(kv) != 2 {
+			continue
+		}
+		switch kv[0] {
+		case w3cStateSpanIDField:
+			spanID = kv[1]
+		case w3cStateWeightField:
+			weight, _ = strconv.Atoi(kv[1])
+		}
+	}
+	return spanID, weight
+}
+
+// W3CTraceContextInjector writes traceparent and tracestate headers.
+// spanId generated by aitracer is 32 characters, so the last 16 characters are used as parent-id
+// and the full spanId is carried in the apminsight member of tracestate. appId and origin in baggage are carried in tracestate too.
+// Members of other vendors from upstream are kept after members of aitracer.
+type W3CTraceContextInjector struct{}
+
+var _ Injector = &W3CTraceContextInjector{}
+
+func (injector *W3CTraceContextInjector) Inject(sc SpanContext, carrier interface{}) error {
+	c, ok := carrier.(HTTPHeadersCarrier)
+	if !ok {
+		return ErrInvalidCarrier
+	}
+	traceID := strings.ToLower(sc.TraceID())
+	spanID := strings.ToLower(sc.SpanID())
+	parentID := spanID
+	if len(parentID) > w3cSpanIDLength {
+		parentID = parentID[len(parentID)-w3cSpanIDLength:]
+	}
+	if !internal.IsValidTraceID(traceID) || !internal.IsValidSpanID(parentID) {
+		return ErrInvalidSpanContext
+	}
+
+	traceParent := internal.TraceParent{
+		Version:      w3cVersion,
+		TraceID:      traceID,
+		ParentSpanID: parentID,
+	}
+	traceState := internal.TraceState{}
+	strategy, weight := sc.Sample()
+	if strategy == SampleStrategySampled {
+		traceParent.TraceFlags |= w3cFlagsSampled
+	} else {
+		weight = 0
+	}
+	fullSpanID := ""
+	if parentID != spanID {
+		fullSpanID = spanID
+	}
+	traceState.Set(w3cStateVendorKey, formatW3CVendorState(fullSpanID, weight))
+	sc.ForeachBaggageItem(func(key string, value string) bool {
+		switch key {
+		case defaultAppIDBaggageKey:
+			traceState.SetAppID(value)
+		case defaultOriginBaggageKey:
+			traceState.SetOrigin(value)
+		}
+		return true
+	})
+	if tsc, ok := sc.(w3cTraceStateCarrier); ok { // own members go first, and members exceeding limit are dropped from the end
+		for _, m := range tsc.w3cTraceState().Members {
+			if !isOwnTraceStateKey(m.Key) {
+				traceState.Members = append(traceState.Members, m)
+			}
+		}
+	}
+
+	c.Set(w3cTraceparentHeader, internal.DefaultSimpleW3CFormatParser.FormatTraceParent(traceParent))
+	if s := internal.DefaultSimpleW3CFormatParser.FormatTraceState(traceState); s != "" {
+		c.Set(w3cTracestateHeader, s)
+	}
+	return nil
+}
+
+// W3CTraceContextExtractor reads traceparent and tracestate headers.
+// sampled flag of traceparent is treated as server side sample result of upstream.
+type W3CTraceContextExtractor struct{}
+
+var _ Extractor = &W3CTraceContextExtractor{}
+
+func (extractor *W3CTraceContextExtractor) Extract(carrier interface{}) (SpanContext, error) {
+	c, ok := carrier.(HTTPHeadersCarrier)
+	if !ok {
+		return nil, ErrInvalidCarrier
+	}
+	var traceParentStr, traceStateStr string
+	_ = c.ForeachKey(func(key, val string) error {
+		switch strings.ToLower(key) {
+		case w3cTraceparentHeader:
+			traceParentStr = val
+		case w3cTracestateHeader:
+			if traceStateStr != "" { // tracestate may be split into multiple headers
+				traceStateStr += ","
+			}
+			traceStateStr += val
+		}
+		return nil
+	})
+	if traceParentStr == "" {
+		return nil, nil
+	}
+	traceParent, err := internal.DefaultSimpleW3CFormatParser.ParseTraceParent(strings.TrimSpace(traceParentStr))
+	if err != nil || !internal.IsValidTraceID(traceParent.TraceID) || !internal.IsValidSpanID(traceParent.ParentSpanID) {
+		return nil, nil
+	}
+
+	ctx := HTTPHeaderExtractSpanContext{
+		traceID:        traceParent.TraceID,
+		spanID:         traceParent.ParentSpanID,
+		sampleStrategy: SampleStrategyNotSampled,
+		sampleFlags:    SampleFlagsUnknown, // determined by sampleStrategy
+	}
+	traceState, _ := internal.DefaultSimpleW3CFormatParser.ParseTraceState(strings.Replace(traceStateStr, " ", "", -1))
+	ctx.traceState = traceState
+	spanID, weight := parseW3CVendorState(traceState.Get(w3cStateVendorKey))
+	if traceParent.TraceFlags&w3cFlagsSampled != 0 {
+		ctx.sampleStrategy = SampleStrategySampled
+		ctx.sampleWeight = 1
+		if weight > 0 {
+			ctx.sampleWeight = weight
+		}
+	}
+	if len(spanID) > w3cSpanIDLength && strings.HasSuffix(spanID, traceParent.ParentSpanID) {
+		ctx.spanID = spanID
+	}
+	for key, value := range map[string]string{
+		defaultAppIDBaggageKey:  traceState.GetAppID(),
+		defaultOriginBaggageKey: traceState.GetOrigin(),
+	} {
+		if value == "" {
+			continue
+		}
+		if ctx.baggage == nil {
+			ctx.baggage = make(map[string]string)
+		}
+		ctx.baggage[key] = value
+	}
+	return &ctx, nil
+}
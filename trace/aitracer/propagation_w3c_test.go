@@ -0,0 +1,65 @@
+package aitracer
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestW3CTraceContextPropagation(t *testing.T) {
+	sc := &HTTPHeaderExtractSpanContext{
+		traceID:        "2023010112000000a1b2c3d4e5f60718",
+		spanID:         "20230101120000001122334455667788",
+		sampleStrategy: SampleStrategySampled,
+		sampleWeight:   10,
+		baggage:        map[string]string{defaultAppIDBaggageKey: "app", defaultOriginBaggageKey: "web"},
+	}
+	carrier := HTTPHeadersCarrier(http.Header{})
+	assert.Nil(t, (&W3CTraceContextInjector{}).Inject(sc, carrier))
+	assert.Equal(t, "00-2023010112000000a1b2c3d4e5f60718-1122334455667788-01", http.Header(carrier).Get("traceparent"))
+	assert.Contains(t, http.Header(carrier).Get("tracestate"), "apminsight=s:20230101120000001122334455667788;w:10")
+
+	extracted, err := (&W3CTraceContextExtractor{}).Extract(carrier)
+	assert.Nil(t, err)
+	assert.Equal(t, sc.traceID, extracted.TraceID())
+	assert.Equal(t, sc.spanID, extracted.SpanID())
+	strategy, weight := extracted.Sample()
+	assert.Equal(t, SampleStrategySampled, strategy)
+	assert.Equal(t, 10, weight)
+	baggage := map[string]string{}
+	extracted.ForeachBaggageItem(func(k, v string) bool {
+		baggage[k] = v
+		return true
+	})
+	assert.Equal(t, sc.baggage, baggage)
+
+	// traceparent from other vendors
+	carrier = HTTPHeadersCarrier(http.Header{})
+	carrier.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	extracted, err = (&W3CTraceContextExtractor{}).Extract(carrier)
+	assert.Nil(t, err)
+	assert.Equal(t, "00f067aa0ba902b7", extracted.SpanID())
+	strategy, _ = extracted.Sample()
+	assert.Equal(t, SampleStrategyNotSampled, strategy)
+
+	// members of other vendors are kept after own members
+	carrier = HTTPHeadersCarrier(http.Header{})
+	carrier.Set("Traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	carrier.Set("Tracestate", "rojo=00f067aa0ba902b7,apminsight=w:5,congo=t61rcWkgMzE")
+	extracted, err = (&W3CTraceContextExtractor{}).Extract(carrier)
+	assert.Nil(t, err)
+	_, weight = extracted.Sample()
+	assert.Equal(t, 5, weight)
+	tr := NewTracer(Http, "svc")
+	sp := tr.StartServerSpan("op", ChildOf(extracted))
+	tc := sp.Context().(*spanContext).traceContext
+	sp.Finish()
+	carrier = HTTPHeadersCarrier(http.Header{})
+	assert.Nil(t, (&W3CTraceContextInjector{}).Inject(&spanContext{spanID: sc.spanID, traceContext: tc}, carrier))
+	assert.Equal(t, "apminsight=s:20230101120000001122334455667788;w:5,rojo=00f067aa0ba902b7,congo=t61rcWkgMzE", http.Header(carrier).Get("tracestate"))
+
+	carrier.Set("Traceparent", "00-00000000000000000000000000000000-00f067aa0ba902b7-01")
+	extracted, _ = (&W3CTraceContextExtractor{}).Extract(carrier)
+	assert.Nil(t, extracted)
+}
@@ -129,10 +129,14 @@ func (r *Monitor) Start() {
 }
 
 func (r *Monitor) Close() {
+	r.Stop()
 	r.metricsClient.Close()
+}
+
+// Stop stops collecting runtime metrics like Close, but leaves metrics client to be closed by caller
+func (r *Monitor) Stop() {
 	close(r.closeChan)
 	r.wg.Wait()
-
 }
 
 func (r *Monitor) readStats() *stats {
@@ -1,11 +1,11 @@
 package aitracer
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
 	"runtime"
-	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -38,6 +38,10 @@ type span struct {
 	errLock       sync.Mutex
 	ErrorInfoList []*ErrorInfo
 
+	eventsLock sync.Mutex
+	events     []*spanEvent
+	links      []SpanLink
+
 	startTime  time.Time
 	finishTime time.Time
 	duration   time.Duration
@@ -49,6 +53,8 @@ type span struct {
 
 	spanContext spanContext
 
+	pprofParent context.Context // pprof labels of goroutine are restored to it when finished
+
 	finished  int64
 	collected int64
 }
@@ -61,6 +67,18 @@ type ErrorInfo struct {
 	ErrorTags              map[string]string
 }
 
+type spanEvent struct {
+	name       string
+	timestamp  time.Time
+	attributes map[string]string
+}
+
+// events and links more than limits are dropped, in case that span grows without bound
+const (
+	maxSpanEvents = 128
+	maxSpanLinks  = 128
+)
+
 const (
 	aiCalledThroughput = "apminsight.service.trace.called.throughput"
 	aiCalledLatency    = "apminsight.service.trace.called.latency.us"
@@ -78,43 +96,26 @@ func (s *span) emitMetric() {
 	if mc == nil {
 		return
 	}
+	var tagKeys []string
 	if s.spanType == serverSpanType {
-		tags := map[string]string{}
-		tags["service_type"] = t.serviceType
-		tags["service"] = t.service
-		tags["resource"] = tc.resource
-		tags["status"] = strconv.FormatInt(s.status, 10)
-		tags["instance_id"] = t.instanceId
-
-		// extract span tags and add to metric
-		for _, k := range t.metricTagKeysRegister.GetServerTagKeys() {
-			if v, ok := s.GetTagString(k); ok && v != "" {
-				tags[k] = v
-			}
-		}
+		tagKeys = t.metricTagKeysRegister.GetServerTagKeys()
+	} else {
+		tagKeys = t.metricTagKeysRegister.GetClientTagKeys()
+	}
+	latency := float64(s.duration.Microseconds())
+	if h := t.spanMetrics.get(mc, s, tagKeys); h != nil {
+		h.throughput.Add(1)
+		h.latency.Observe(latency)
+		return
+	}
 
+	tags := s.metricTags(tagKeys)
+	if s.spanType == serverSpanType {
 		_ = mc.EmitCounter(aiCalledThroughput, 1, tags)
-		_ = mc.EmitTimer(aiCalledLatency, float64(s.duration.Microseconds()), tags)
+		_ = mc.EmitTimer(aiCalledLatency, latency, tags)
 	} else {
-		tags := map[string]string{}
-		tags["service_type"] = t.serviceType
-		tags["service"] = t.service
-		tags["resource"] = tc.resource
-		tags["status"] = strconv.FormatInt(s.status, 10)
-		tags["instance_id"] = t.instanceId
-		tags["call_service_type"] = s.clientType
-		tags["call_service"] = s.clientService
-		tags["call_resource"] = s.clientResource
-
-		// extract span tags and add to metric
-		for _, k := range t.metricTagKeysRegister.GetClientTagKeys() {
-			if v, ok := s.GetTagString(k); ok && v != "" {
-				tags[k] = v
-			}
-		}
-
 		_ = mc.EmitCounter(aiCallThroughput, 1, tags)
-		_ = mc.EmitTimer(aiCallLatency, float64(s.duration.Microseconds()), tags)
+		_ = mc.EmitTimer(aiCallLatency, latency, tags)
 	}
 }
 
@@ -141,21 +142,12 @@ func (s *span) Finish() {
 	s.fillTag()
 	//recordPanic.
 	//if panic cause process crash directly, metric/trace may not have time to send out.
-	//this will be resolved by globalPanicCapture
+	//use CapturePanics or SafeGo to flush them before crash
 	if err := recover(); err != nil {
 		defer panic(err)
-		errorInfo := ErrorInfo{
-			ErrorKind:              ErrorKindPanic,
-			ErrorMessage:           fmt.Sprint(err),
-			ErrorOccurTimeMilliSec: time.Now().Unix()*1e3 + int64(time.Now().Nanosecond())/1e6,
-			ErrorTags:              map[string]string{internal.GoErrorType: getErrorType(err)},
-		}
-		errorInfo.ErrorStack = getStackTrace()
-		s.errLock.Lock()
-		s.ErrorInfoList = append(s.ErrorInfoList, &errorInfo)
-		s.errLock.Unlock()
-		s.status = 1
+		s.recordPanic(ErrorKindPanic, err)
 	}
+	s.restorePprofLabels()
 	// emit metric
 	s.emitMetric()
 	s.spanContext.traceContext.finishSpan()
@@ -178,26 +170,25 @@ func (s *span) FinishWithOption(opt FinishSpanOption) {
 	if !opt.DisablePanicCapture {
 		if err := recover(); err != nil {
 			defer panic(err)
-			errorInfo := ErrorInfo{
-				ErrorKind:              ErrorKindPanic,
-				ErrorMessage:           fmt.Sprint(err),
-				ErrorOccurTimeMilliSec: time.Now().Unix()*1e3 + int64(time.Now().Nanosecond())/1e6,
-				ErrorTags:              map[string]string{internal.GoErrorType: getErrorType(err)},
-			}
-			errorInfo.ErrorStack = getStackTrace()
-			s.errLock.Lock()
-			s.ErrorInfoList = append(s.ErrorInfoList, &errorInfo)
-			s.errLock.Unlock()
-			s.status = 1
+			s.recordPanic(ErrorKindPanic, err)
 		}
 	}
+	s.restorePprofLabels()
 	s.emitMetric()
 	s.spanContext.traceContext.finishSpan()
 }
 
+// sqlClientTypes are client types of sql databases, whose spans are flagged by db.slow_query
+var sqlClientTypes = map[string]bool{
+	MySQL:      true,
+	PostgreSQL: true,
+	SQLServer:  true,
+	SQLite:     true,
+	ClickHouse: true,
+}
+
 func (s *span) fillTag() {
-	switch s.clientType {
-	case MySQL:
+	if sqlClientTypes[s.clientType] {
 		isSlow := "0"
 		dynamicConfig := s.spanContext.traceContext.tracer.getDynamicConfig()
 		if dynamicConfig != nil {
@@ -205,7 +196,7 @@ func (s *span) fillTag() {
 				isSlow = "1"
 			}
 		}
-		s.SetTagString("db.slow_query", isSlow)
+		s.SetTagString(DbSlowQuery, isSlow)
 	}
 	// must add sdk info to every span. this info is used to handler stack
 	s.SetTagString(internal.SdkLanguage, internal.Go) //todo: add version
@@ -225,6 +216,7 @@ func (s *span) RecordError(err error, opts ...RecordOption) {
 		ErrorOccurTimeMilliSec: time.Now().Unix()*1e3 + int64(time.Now().Nanosecond())/1e6,
 		ErrorTags:              map[string]string{internal.GoErrorType: getErrorType(err)},
 	}
+	s.tagCrashCause(&errorInfo)
 	if c.RecordStack && c.Stack == "" {
 		errorInfo.ErrorStack = getStackTrace()
 	} else if c.Stack != "" {
@@ -235,6 +227,64 @@ func (s *span) RecordError(err error, opts ...RecordOption) {
 	s.ErrorInfoList = append(s.ErrorInfoList, &errorInfo)
 }
 
+// recordPanic records err recovered from panic with stack, and sets status to error
+func (s *span) recordPanic(errorKind ErrorKind, err interface{}) {
+	errorInfo := ErrorInfo{
+		ErrorKind:              errorKind,
+		ErrorMessage:           fmt.Sprint(err),
+		ErrorOccurTimeMilliSec: time.Now().Unix()*1e3 + int64(time.Now().Nanosecond())/1e6,
+		ErrorTags:              map[string]string{internal.GoErrorType: getErrorType(err)},
+	}
+	errorInfo.ErrorStack = getStackTrace()
+	s.tagCrashCause(&errorInfo)
+	s.errLock.Lock()
+	s.ErrorInfoList = append(s.ErrorInfoList, &errorInfo)
+	s.errLock.Unlock()
+	s.status = 1
+}
+
+// tagCrashCause tags errorInfo whose error type is direct cause of crash by ErrorPolicy
+func (s *span) tagCrashCause(errorInfo *ErrorInfo) {
+	tc := s.spanContext.traceContext
+	if tc == nil || tc.tracer == nil {
+		return
+	}
+	if tc.tracer.ErrorPolicy().isCrashCauseType(errorInfo.ErrorTags[internal.GoErrorType]) {
+		errorInfo.ErrorTags[internal.DirectCauseCrash] = "1"
+	}
+}
+
+func (s *span) AddEvent(name string, attrs map[string]string, timestamp time.Time) {
+	if s == nil || s.isFinished() {
+		return
+	}
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	s.eventsLock.Lock()
+	defer s.eventsLock.Unlock()
+	if len(s.events) >= maxSpanEvents {
+		return
+	}
+	s.events = append(s.events, &spanEvent{
+		name:       name,
+		timestamp:  timestamp,
+		attributes: attrs,
+	})
+}
+
+func (s *span) AddLink(sc SpanContext, attrs map[string]string) {
+	if s == nil || sc == nil || s.isFinished() {
+		return
+	}
+	s.eventsLock.Lock()
+	defer s.eventsLock.Unlock()
+	if len(s.links) >= maxSpanLinks {
+		return
+	}
+	s.links = append(s.links, SpanLink{SpanContext: sc, Attributes: attrs})
+}
+
 func (s *span) SetStatus(status int64) {
 	if s == nil || s.isFinished() {
 		return
@@ -1,6 +1,10 @@
 package aitracer
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/internal"
+)
 
 type spanContext struct {
 	spanID       string
@@ -28,6 +32,10 @@ func (sc *spanContext) SampleFlags() SampleFlags {
 	return sc.traceContext.sampleFlags
 }
 
+func (sc *spanContext) w3cTraceState() internal.TraceState {
+	return sc.traceContext.traceState
+}
+
 func (sc *spanContext) ClientSampled() bool {
 	return sc.traceContext.clientSampled
 }
@@ -0,0 +1,107 @@
+package aitracer
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/volcengine/apminsight-server-sdk-go/metrics"
+)
+
+// maxSpanMetricHandles limits handles cached, metrics are emitted with tags map if exceeded
+const maxSpanMetricHandles = 10000
+
+// spanMetricKey is tags of span metrics except service and instance, which are the same for a tracer
+type spanMetricKey struct {
+	server          bool
+	resource        string
+	status          int64
+	callServiceType string
+	callService     string
+	callResource    string
+	extraTags       string // span tags registered by metricTagKeysRegister
+}
+
+type spanMetricHandles struct {
+	throughput *metrics.Counter
+	latency    *metrics.Timer
+}
+
+// spanMetrics caches metric handles of spans, so that tags map is not built for each span
+type spanMetrics struct {
+	handles sync.Map // spanMetricKey -> *spanMetricHandles
+	size    int64
+}
+
+// get returns cached handles of metrics of s, or creates them. nil is returned if cache is full
+func (sm *spanMetrics) get(mc *metrics.MetricsClient, s *span, tagKeys []string) *spanMetricHandles {
+	key := s.metricKey(tagKeys)
+	if h, ok := sm.handles.Load(key); ok {
+		return h.(*spanMetricHandles)
+	}
+	if atomic.LoadInt64(&sm.size) >= maxSpanMetricHandles {
+		return nil
+	}
+	throughput, latency := aiCallThroughput, aiCallLatency
+	if key.server {
+		throughput, latency = aiCalledThroughput, aiCalledLatency
+	}
+	m := s.metricTags(tagKeys)
+	h, loaded := sm.handles.LoadOrStore(key, &spanMetricHandles{
+		throughput: mc.Counter(throughput, m),
+		latency:    mc.Timer(latency, m),
+	})
+	if !loaded {
+		atomic.AddInt64(&sm.size, 1)
+	}
+	return h.(*spanMetricHandles)
+}
+
+func (s *span) metricKey(tagKeys []string) spanMetricKey {
+	key := spanMetricKey{
+		server:   s.spanType == serverSpanType,
+		resource: s.spanContext.traceContext.resource,
+		status:   s.status,
+	}
+	if !key.server {
+		key.callServiceType = s.clientType
+		key.callService = s.clientService
+		key.callResource = s.clientResource
+	}
+	var sb strings.Builder
+	for _, k := range tagKeys {
+		if v, ok := s.GetTagString(k); ok && v != "" {
+			sb.WriteString(k)
+			sb.WriteByte('=')
+			sb.WriteString(v)
+			sb.WriteByte(0)
+		}
+	}
+	key.extraTags = sb.String()
+	return key
+}
+
+func (s *span) metricTags(tagKeys []string) map[string]string {
+	tc := s.spanContext.traceContext
+	t := tc.tracer
+	tags := map[string]string{}
+	tags["service_type"] = t.serviceType
+	tags["service"] = t.service
+	tags["resource"] = tc.resource
+	tags["status"] = strconv.FormatInt(s.status, 10)
+	tags["instance_id"] = t.instanceId
+	if s.spanType != serverSpanType {
+		tags["call_service_type"] = s.clientType
+		tags["call_service"] = s.clientService
+		tags["call_resource"] = s.clientResource
+	}
+
+	// extract span tags and add to metric
+	for _, k := range tagKeys {
+		if v, ok := s.GetTagString(k); ok && v != "" {
+			tags[k] = v
+		}
+	}
+	return tags
+}
@@ -0,0 +1,51 @@
+package aitracer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpanEventsAndLinks(t *testing.T) {
+	tr := NewTracer(Http, "svc").(*tracer)
+	linked := tr.StartServerSpan("producer")
+
+	s := tr.StartServerSpan("consumer", LinkTo(linked.Context(), map[string]string{"mq.offset": "1"}))
+	ts := time.Unix(1, 1000)
+	s.AddEvent("retry", map[string]string{"attempt": "2"}, ts)
+	s.AddLink(nil, nil)
+	s.Finish()
+	s.AddEvent("after finish", nil, time.Time{})
+
+	trace := <-tr.traceChan
+	span := trace.Spans[0]
+	assert.Equal(t, 1, len(span.Events))
+	assert.Equal(t, "retry", span.Events[0].Name)
+	assert.Equal(t, int64(1000001), span.Events[0].TimeMicrosecond)
+	assert.Equal(t, "2", span.Events[0].Attributes["attempt"])
+	assert.Equal(t, 1, len(span.Links))
+	assert.Equal(t, linked.Context().TraceID(), span.Links[0].TraceId)
+	assert.Equal(t, linked.Context().SpanID(), span.Links[0].SpanId)
+	assert.Equal(t, "1", span.Links[0].Attributes["mq.offset"])
+}
+
+func TestSpanMetrics(t *testing.T) {
+	tr := NewTracer(Http, "svc", WithMetrics(true)).(*tracer)
+	for i := 0; i < 3; i++ {
+		s, ctx := tr.StartServerSpanFromContext(context.Background(), "server", ServerResourceAs("/a"))
+		c, _ := tr.StartClientSpanFromContext(ctx, "client", ClientResourceAs(MySQL, "db", "select"))
+		c.Finish()
+		s.Finish()
+	}
+	assert.Equal(t, int64(2), tr.spanMetrics.size) // handles of server and client spans are cached
+
+	s := tr.StartServerSpan("server", ServerResourceAs("/a")).(*span)
+	s.Finish()
+	h := tr.spanMetrics.get(tr.metricsClient, s, nil)
+	tags := s.metricTags(nil)
+	assert.Equal(t, tr.metricsClient.Counter(aiCalledThroughput, tags), h.throughput)
+	assert.Equal(t, "/a", tags["resource"])
+	assert.Equal(t, "0", tags["status"])
+}
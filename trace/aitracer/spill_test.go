@@ -0,0 +1,36 @@
+package aitracer
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
+)
+
+func TestSpillTrace(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	tr := NewTracer(Http, "svc", WithSenderChanSize(1), WithSpillBuffer(dir, 1<<20, time.Hour)).(*tracer)
+	assert.NotNil(t, tr.spillChan)
+
+	// traces are queued to spill goroutine when traceChan is full, and written to disk by it
+	tr.dropTrace(&trace_models.Trace{TraceId: "a"})
+	assert.Equal(t, 1, len(tr.spillChan))
+	assert.True(t, tr.traceOverflow.Empty())
+
+	tr.spillWg.Add(1)
+	go tr.runSpill()
+	close(tr.spillDone)
+	tr.spillWg.Wait()
+	assert.False(t, tr.traceOverflow.Empty())
+	assert.NotPanics(t, func() { // spans may finish after stopped
+		tr.dropTrace(&trace_models.Trace{TraceId: "b"})
+	})
+	tr.traceOverflow.Close()
+	tr.traceSpill.Close()
+}
@@ -0,0 +1,115 @@
+package aitracer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
+	"github.com/volcengine/apminsight-server-sdk-go/metrics"
+)
+
+// self stats metric names
+const (
+	metricSDKEnqueued    = "apminsight.sdk.enqueued"
+	metricSDKDropped     = "apminsight.sdk.dropped"
+	metricSDKSent        = "apminsight.sdk.sent"
+	metricSDKEncodeError = "apminsight.sdk.encode_error"
+	metricSDKConnError   = "apminsight.sdk.conn_error"
+
+	selfStatsInterval = 30 * time.Second
+)
+
+// PipelineStats counts items of a pipeline since process start.
+// Enqueued, Dropped, Sent and EncodeError are in number of traces, logs or metrics. ConnError is number of failed connections or requests.
+// Items spilled to disk (see WithSpillBuffer) are counted as Sent after they are replayed.
+type PipelineStats struct {
+	Enqueued    int64
+	Dropped     int64
+	Sent        int64
+	EncodeError int64
+	ConnError   int64
+}
+
+// SDKStats is stats of the SDK itself, which helps to find data lost by SDK
+type SDKStats struct {
+	Trace  PipelineStats
+	Log    PipelineStats
+	Metric PipelineStats
+}
+
+// Stats returns process-wide stats of traces, logs and metrics, shared by all tracers and metrics clients
+func Stats() SDKStats {
+	return SDKStats{
+		Trace:  PipelineStats(selfstats.Trace.Snapshot()),
+		Log:    PipelineStats(selfstats.Log.Snapshot()),
+		Metric: PipelineStats(selfstats.Metric.Snapshot()),
+	}
+}
+
+// selfStatsReporter emits increments of self stats as apminsight.sdk.* counters with tag pipeline
+type selfStatsReporter struct {
+	metricsClient *metrics.MetricsClient
+	tags          map[string]string
+
+	prev map[string]selfstats.Snapshot
+
+	closeChan chan struct{}
+	wg        sync.WaitGroup
+}
+
+func newSelfStatsReporter(serviceType, service, instanceId string, mc *metrics.MetricsClient) *selfStatsReporter {
+	return &selfStatsReporter{
+		metricsClient: mc,
+		tags: map[string]string{
+			"service_type": serviceType,
+			"service":      service,
+			"instance_id":  instanceId,
+		},
+		prev:      make(map[string]selfstats.Snapshot),
+		closeChan: make(chan struct{}),
+	}
+}
+
+func (r *selfStatsReporter) Start() {
+	r.metricsClient.Start()
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		tc := time.NewTicker(selfStatsInterval)
+		defer tc.Stop()
+		for {
+			select {
+			case <-tc.C:
+				r.report()
+			case <-r.closeChan:
+				r.report()
+				return
+			}
+		}
+	}()
+}
+
+// Stop reports the last stats and stops. metricsClient is closed by tracer
+func (r *selfStatsReporter) Stop() {
+	close(r.closeChan)
+	r.wg.Wait()
+}
+
+func (r *selfStatsReporter) report() {
+	for _, name := range []string{selfstats.PipelineTrace, selfstats.PipelineLog, selfstats.PipelineMetric} {
+		cur := selfstats.Get(name).Snapshot()
+		delta := cur.Sub(r.prev[name])
+		r.prev[name] = cur
+
+		tags := make(map[string]string, len(r.tags)+1)
+		for k, v := range r.tags {
+			tags[k] = v
+		}
+		tags["pipeline"] = name
+		_ = r.metricsClient.EmitCounter(metricSDKEnqueued, float64(delta.Enqueued), tags)
+		_ = r.metricsClient.EmitCounter(metricSDKDropped, float64(delta.Dropped), tags)
+		_ = r.metricsClient.EmitCounter(metricSDKSent, float64(delta.Sent), tags)
+		_ = r.metricsClient.EmitCounter(metricSDKEncodeError, float64(delta.EncodeError), tags)
+		_ = r.metricsClient.EmitCounter(metricSDKConnError, float64(delta.ConnError), tags)
+	}
+}
@@ -0,0 +1,67 @@
+package aitracer
+
+import (
+	"time"
+)
+
+// TailSamplingConfig controls tail-based sampling.
+// When enabled, traces dropped by the head sampler are re-checked once every span has finished,
+// and are kept if any span has errors, a non-zero status, or a duration above the latency threshold.
+type TailSamplingConfig struct {
+	Enable bool
+
+	LatencyThreshold          time.Duration            // default threshold. non-positive value disables latency check
+	ResourceLatencyThresholds map[string]time.Duration // threshold of specified resource, overrides LatencyThreshold
+}
+
+type tailSampler struct {
+	latencyThreshold          time.Duration
+	resourceLatencyThresholds map[string]time.Duration
+}
+
+func newTailSampler(config TailSamplingConfig) *tailSampler {
+	if !config.Enable {
+		return nil
+	}
+	ts := &tailSampler{
+		latencyThreshold:          config.LatencyThreshold,
+		resourceLatencyThresholds: make(map[string]time.Duration, len(config.ResourceLatencyThresholds)),
+	}
+	for resource, threshold := range config.ResourceLatencyThresholds { // copy, options may be reused by caller
+		ts.resourceLatencyThresholds[resource] = threshold
+	}
+	return ts
+}
+
+func (ts *tailSampler) getLatencyThreshold(resource string) time.Duration {
+	if threshold, ok := ts.resourceLatencyThresholds[resource]; ok {
+		return threshold
+	}
+	return ts.latencyThreshold
+}
+
+// shouldKeep is called after all spans of tc have finished
+func (ts *tailSampler) shouldKeep(tc *traceContext) bool {
+	threshold := ts.getLatencyThreshold(tc.resource)
+
+	tc.spansLock.Lock()
+	defer tc.spansLock.Unlock()
+	for _, s := range tc.spans {
+		if s == nil {
+			continue
+		}
+		if s.status != StatusCodeOK {
+			return true
+		}
+		if threshold > 0 && s.duration > threshold {
+			return true
+		}
+		s.errLock.Lock()
+		hasError := len(s.ErrorInfoList) > 0
+		s.errLock.Unlock()
+		if hasError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package aitracer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sampler"
+)
+
+func TestTailSampler(t *testing.T) {
+	assert.Nil(t, newTailSampler(TailSamplingConfig{}))
+
+	ts := newTailSampler(TailSamplingConfig{
+		Enable:                    true,
+		LatencyThreshold:          time.Second,
+		ResourceLatencyThresholds: map[string]time.Duration{"/slow": 10 * time.Second},
+	})
+
+	newTc := func(resource string, s *span) *traceContext {
+		return &traceContext{resource: resource, spans: []*span{s}}
+	}
+
+	assert.False(t, ts.shouldKeep(newTc("/a", &span{duration: time.Millisecond})))
+	assert.True(t, ts.shouldKeep(newTc("/a", &span{duration: 2 * time.Second})))
+	assert.False(t, ts.shouldKeep(newTc("/slow", &span{duration: 2 * time.Second})))
+	assert.True(t, ts.shouldKeep(newTc("/a", &span{status: StatusCodeError})))
+
+	s := &span{}
+	s.RecordError(errors.New("err"))
+	assert.True(t, ts.shouldKeep(newTc("/a", s)))
+}
+
+func TestTailSampledTrace(t *testing.T) {
+	tr := NewTracer(Http, "svc", WithTailSampling(time.Hour),
+		WithSamplerRules(trace_sampler.SamplerRule{Strategy: trace_sampler.SamplerStrategyRatio, Value: 0})).(*tracer)
+
+	s := tr.StartServerSpan("server")
+	s.RecordError(errors.New("err"))
+	s.Finish()
+
+	trace := <-tr.traceChan
+	assert.True(t, trace.TailSampled)
+	assert.Equal(t, int32(SampleFlagsServerSampled), trace.SampleFlags)
+	assert.Equal(t, int32(1), trace.SampleWeight)
+}
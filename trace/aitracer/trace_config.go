@@ -1,6 +1,8 @@
 package aitracer
 
 import (
+	"time"
+
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
 )
 
@@ -22,6 +24,8 @@ const (
 	defaultServiceRegisterSock = "/var/run/apminsight/comm.sock"
 
 	defaultMetricSock = "/var/run/apminsight/metrics.sock"
+
+	stopGracePeriod = 500 * time.Millisecond // used by StopWithContext for metrics and logs after ctx is done
 )
 
 func newDefaultTracerConfig() TracerConfig {
@@ -59,6 +63,17 @@ func newDefaultTracerConfig() TracerConfig {
 				Injector:  &BinaryCarrierInjector{},
 				Extractor: &BinaryCarrierExtractor{},
 			},
+			{
+				Format:    W3CTraceContext,
+				Injector:  &W3CTraceContextInjector{},
+				Extractor: &W3CTraceContextExtractor{},
+			},
+			{
+				Format:    B3,
+				Injector:  &B3Injector{},
+				Extractor: &B3Extractor{},
+			},
 		},
+		CompositeFormats: []interface{}{HTTPHeaders, W3CTraceContext, B3},
 	}
 }
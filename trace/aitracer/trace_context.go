@@ -1,6 +1,10 @@
 package aitracer
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/internal"
+)
 
 type traceContext struct {
 	traceID  string
@@ -13,6 +17,8 @@ type traceContext struct {
 
 	sampleFlags SampleFlags
 
+	traceState internal.TraceState // tracestate of upstream, members of other vendors are propagated as is
+
 	tracer *tracer
 
 	spansLock   sync.Mutex
@@ -38,3 +44,16 @@ func (tc *traceContext) finishSpan() {
 		tc.tracer.collect(tc)
 	}
 }
+
+// finishAll finishes spans which are not finished with error status, so that the trace is emitted before crash
+func (tc *traceContext) finishAll() {
+	tc.spansLock.Lock()
+	spans := make([]*span, len(tc.spans))
+	copy(spans, tc.spans)
+	tc.spansLock.Unlock()
+	for _, s := range spans {
+		if !s.isFinished() {
+			s.FinishWithOption(FinishSpanOption{Status: StatusCodeError, DisablePanicCapture: true})
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package trace_sampler
 
 import (
+	"errors"
+	"math"
+	"strings"
 	"sync"
 	"time"
 )
@@ -16,11 +19,36 @@ const (
 type SamplerConfig struct {
 	Strategy int
 	Value    float64
+
+	// Rules are matched in order and the first matched rule decides. Strategy/Value is used when no rule matches
+	Rules []SamplerRule
+}
+
+// SamplerRule matches traces by resource, operation and baggage items. Empty field matches any.
+type SamplerRule struct {
+	Resource  string            // exact match, or prefix match if ends with '*'
+	Operation string            // exact match, or prefix match if ends with '*'
+	Baggage   map[string]string // all items should be matched
+	Strategy  int
+	Value     float64
+}
+
+// SampleAttributes are attributes of trace used to match SamplerRule
+type SampleAttributes struct {
+	Resource  string
+	Operation string
+	Baggage   map[string]string
 }
 
 type Sampler struct {
 	rwlock          sync.RWMutex
 	internalSampler internalSampler
+	rules           []*ruleSampler
+}
+
+type ruleSampler struct {
+	rule            SamplerRule
+	internalSampler internalSampler
 }
 
 func New() *Sampler {
@@ -39,30 +67,105 @@ func (s *Sampler) Sample() (bool, int) {
 	return s.internalSampler.Sample()
 }
 
+// SampleWith find the first rule matching attrs to sample, fallback to default strategy if no rule matches
+func (s *Sampler) SampleWith(attrs SampleAttributes) (bool, int) {
+	s.rwlock.RLock()
+	defer func() {
+		s.rwlock.RUnlock()
+	}()
+	for _, rs := range s.rules {
+		if rs.rule.match(attrs) {
+			return rs.internalSampler.Sample()
+		}
+	}
+	return s.internalSampler.Sample()
+}
+
+var ErrInvalidSamplerValue = errors.New("invalid sampler value")
+
+// ValidateSamplerValue checks value is a finite number. Value not greater than 0 is valid, which means sampling nothing
+func ValidateSamplerValue(strategy int, value float64) error {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return ErrInvalidSamplerValue
+	}
+	return nil
+}
+
+// RefreshConfig replaces rules and default strategy. Rules with invalid value are ignored,
+// and default strategy is kept if its value is invalid
 func (s *Sampler) RefreshConfig(config SamplerConfig) {
+	rules := make([]*ruleSampler, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		if ValidateSamplerValue(rule.Strategy, rule.Value) != nil {
+			continue
+		}
+		if is := newInternalSampler(rule.Strategy, rule.Value); is != nil {
+			rules = append(rules, &ruleSampler{rule: rule, internalSampler: is})
+		}
+	}
 	s.rwlock.Lock()
-	switch config.Strategy {
+	if ValidateSamplerValue(config.Strategy, config.Value) == nil {
+		if is := newInternalSampler(config.Strategy, config.Value); is != nil {
+			s.internalSampler = is
+		}
+	}
+	s.rules = rules
+	s.rwlock.Unlock()
+}
+
+// newInternalSampler returns nil if strategy is unknown
+func newInternalSampler(strategy int, value float64) internalSampler {
+	switch strategy {
 	case SamplerStrategyAll:
-		s.internalSampler = &allSampler{}
+		return &allSampler{}
 	case SamplerStrategyRatio:
-		if config.Value <= 0 {
-			s.internalSampler = &noSampler{}
-		} else {
-			s.internalSampler = &ratioSampler{
-				permil: int(config.Value * 1000),
-				weight: int(1 / config.Value),
-			}
+		if value <= 0 {
+			return &noSampler{}
+		}
+		if value >= 1 {
+			return &allSampler{}
+		}
+		return &ratioSampler{
+			permil: int(value * 1000),
+			weight: int(1 / value),
 		}
 	case SamplerStrategyRateLimit:
-		if config.Value <= 0 {
-			s.internalSampler = &noSampler{}
-		} else {
-			s.internalSampler = &ratelimitSampler{
-				interval: time.Second / time.Duration(config.Value),
-			}
+		if value <= 0 {
+			return &noSampler{}
+		}
+		return &ratelimitSampler{
+			interval: time.Duration(float64(time.Second) / value), // value below 1 means less than 1 trace per second
 		}
 	}
-	s.rwlock.Unlock()
+	return nil
+}
+
+func (r *SamplerRule) match(attrs SampleAttributes) bool {
+	if !matchPattern(r.Resource, attrs.Resource) {
+		return false
+	}
+	if !matchPattern(r.Operation, attrs.Operation) {
+		return false
+	}
+	for k, v := range r.Baggage {
+		if attrs.Baggage == nil {
+			return false
+		}
+		if bv, ok := attrs.Baggage[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func matchPattern(pattern, s string) bool {
+	if pattern == "" {
+		return true
+	}
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(s, pattern[:len(pattern)-1])
+	}
+	return pattern == s
 }
 
 type internalSampler interface {
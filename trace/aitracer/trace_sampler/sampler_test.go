@@ -0,0 +1,68 @@
+package trace_sampler
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamplerRules(t *testing.T) {
+	s := New()
+	s.RefreshConfig(SamplerConfig{
+		Strategy: SamplerStrategyAll,
+		Rules: []SamplerRule{
+			{Resource: "/health*", Strategy: SamplerStrategyRatio, Value: 0},
+			{Operation: "grpc.called", Baggage: map[string]string{"tenant": "a"}, Strategy: SamplerStrategyRatio, Value: 0},
+			{Resource: "/unknown", Strategy: 100},
+		},
+	})
+
+	sampled, _ := s.SampleWith(SampleAttributes{Resource: "/healthz"})
+	assert.False(t, sampled)
+
+	sampled, _ = s.SampleWith(SampleAttributes{Operation: "grpc.called", Baggage: map[string]string{"tenant": "a"}})
+	assert.False(t, sampled)
+
+	sampled, _ = s.SampleWith(SampleAttributes{Operation: "grpc.called", Baggage: map[string]string{"tenant": "b"}})
+	assert.True(t, sampled)
+
+	sampled, _ = s.SampleWith(SampleAttributes{Resource: "/unknown"}) // invalid rule is ignored
+	assert.True(t, sampled)
+
+	sampled, _ = s.Sample()
+	assert.True(t, sampled)
+}
+
+func TestSamplerRuleValue(t *testing.T) {
+	assert.Nil(t, ValidateSamplerValue(SamplerStrategyRateLimit, 0.5))
+	assert.Nil(t, ValidateSamplerValue(SamplerStrategyRatio, 0))
+	assert.Nil(t, ValidateSamplerValue(SamplerStrategyRateLimit, 0))
+	assert.Nil(t, ValidateSamplerValue(SamplerStrategyRateLimit, -1))
+	assert.Equal(t, ErrInvalidSamplerValue, ValidateSamplerValue(SamplerStrategyRateLimit, math.Inf(1)))
+	assert.Equal(t, ErrInvalidSamplerValue, ValidateSamplerValue(SamplerStrategyRatio, math.NaN()))
+
+	s := New()
+	assert.NotPanics(t, func() {
+		s.RefreshConfig(SamplerConfig{
+			Strategy: SamplerStrategyRateLimit,
+			Value:    0, // sample nothing
+			Rules: []SamplerRule{
+				{Resource: "/zero", Strategy: SamplerStrategyRateLimit, Value: 0},
+				{Resource: "/invalid", Strategy: SamplerStrategyRateLimit, Value: math.NaN()},
+				{Resource: "/slow", Strategy: SamplerStrategyRateLimit, Value: 0.5},
+			},
+		})
+	})
+	assert.Len(t, s.rules, 2)
+	assert.Equal(t, 2*time.Second, s.rules[1].internalSampler.(*ratelimitSampler).interval)
+	sampled, _ := s.SampleWith(SampleAttributes{Resource: "/zero"})
+	assert.False(t, sampled)
+	sampled, _ = s.SampleWith(SampleAttributes{Resource: "/invalid"}) // invalid rule is ignored, default strategy is used
+	assert.False(t, sampled)
+
+	s.RefreshConfig(SamplerConfig{Strategy: SamplerStrategyRatio, Value: math.Inf(1)}) // invalid, default strategy is kept
+	sampled, _ = s.Sample()
+	assert.False(t, sampled)
+}
@@ -0,0 +1,209 @@
+package trace_sender
+
+import (
+	"strings"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/flush"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/otlphttp"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+)
+
+const (
+	otlpMaxBatchSpans    = 512
+	otlpFlushInterval    = 5 * time.Second
+	otlpScopeName        = "apminsight-server-sdk-go"
+	otlpErrorEventName   = "exception"
+	otlpStatusKey        = "apminsight.status"
+	otlpCallServiceKey   = "apminsight.call_service"
+	otlpCallTypeKey      = "apminsight.call_service_type"
+	otlpCallResourceKey  = "apminsight.call_resource"
+	otlpResourceKey      = "apminsight.resource"
+	otlpErrorKindKey     = "apminsight.error_kind"
+	otlpExceptionMessage = "exception.message"
+	otlpExceptionStack   = "exception.stacktrace"
+)
+
+// NewOTLPTraceSender creates a TraceSender which exports traces to OTLP collector by OTLP/HTTP.
+// spans are batched and flushed when batch is full or every 5 seconds.
+func NewOTLPTraceSender(cfg otlphttp.Config, in chan *trace_models.Trace, l logger.Logger) *TraceSender {
+	if cfg.Endpoint == "" {
+		panic("otlp endpoint is empty")
+	}
+	if l == nil {
+		l = &logger.NoopLogger{}
+	}
+	l.Info("newOTLPTraceSender success")
+	return &TraceSender{
+		logger:        l,
+		in:            in,
+		flushReqs:     flush.NewRequests(),
+		flushInterval: otlpFlushInterval,
+		otlp:          otlphttp.NewClient(cfg),
+	}
+}
+
+func (s *TraceSender) otlpSendLoop() {
+	batch := make([]*trace_models.Trace, 0)
+	spanCount := 0
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.otlp.Export(otlphttp.TracesPath, toOTLPTraces(batch)); err != nil {
+			s.logger.Error("export otlp traces err %v", err)
+			selfstats.Trace.AddConnError(1)
+			selfstats.Trace.AddDropped(int64(len(batch)))
+		} else {
+			selfstats.Trace.AddSent(int64(len(batch)))
+		}
+		batch = batch[:0]
+		spanCount = 0
+	}
+
+	add := func(item *trace_models.Trace) {
+		if item == nil {
+			return
+		}
+		batch = append(batch, item)
+		spanCount += len(item.Spans)
+		if spanCount >= otlpMaxBatchSpans {
+			flush()
+		}
+	}
+
+	tc := time.NewTicker(s.flushInterval)
+	defer func() {
+		tc.Stop()
+	}()
+	for {
+		select {
+		case <-tc.C:
+			flush()
+		case item, ok := <-s.in:
+			if !ok {
+				flush()
+				return
+			}
+			add(item)
+		case b := <-s.flushReqs.C:
+			s.drainIn(add)
+			flush()
+			b.Done()
+		}
+	}
+}
+
+// toOTLPTraces groups traces by service instance. Fields which have no counterpart in OTLP are set as attributes
+func toOTLPTraces(traces []*trace_models.Trace) *collectortracepb.ExportTraceServiceRequest {
+	req := &collectortracepb.ExportTraceServiceRequest{}
+	resourceIndex := make(map[string]*tracepb.ScopeSpans)
+	for _, t := range traces {
+		key := t.ServiceType + "|" + t.Service + "|" + t.InstanceId
+		scopeSpans, ok := resourceIndex[key]
+		if !ok {
+			scopeSpans = &tracepb.ScopeSpans{Scope: &commonpb.InstrumentationScope{Name: otlpScopeName}}
+			resourceIndex[key] = scopeSpans
+			req.ResourceSpans = append(req.ResourceSpans, &tracepb.ResourceSpans{
+				Resource: otlphttp.Resource(map[string]string{
+					"service.name":            t.Service,
+					"service.instance.id":     t.InstanceId,
+					"container.id":            t.ContainerId,
+					"host.name":               t.Hostname,
+					"apminsight.service_type": t.ServiceType,
+				}),
+				ScopeSpans: []*tracepb.ScopeSpans{scopeSpans},
+			})
+		}
+		traceID := otlphttp.TraceID(t.TraceId)
+		for _, span := range t.Spans {
+			if span == nil {
+				continue
+			}
+			scopeSpans.Spans = append(scopeSpans.Spans, toOTLPSpan(traceID, span))
+		}
+	}
+	return req
+}
+
+func toOTLPSpan(traceID []byte, span *trace_models.Span) *tracepb.Span {
+	startNano := uint64(span.StartTimeMicrosecond) * 1e3
+	s := &tracepb.Span{
+		TraceId:           traceID,
+		SpanId:            otlphttp.SpanID(span.SpanId),
+		ParentSpanId:      otlphttp.SpanID(span.ParentSpanId),
+		Name:              span.OperationName,
+		StartTimeUnixNano: startNano,
+		EndTimeUnixNano:   startNano + uint64(span.DurationMicroseconds)*1e3,
+		Attributes:        otlphttp.StringAttributes(span.ParamString),
+		Status:            &tracepb.Status{},
+	}
+	switch span.SpanType {
+	case trace_models.SpanType_Server:
+		s.Kind = tracepb.Span_SPAN_KIND_SERVER
+		s.Attributes = append(s.Attributes, otlphttp.String(otlpResourceKey, span.Resource))
+	case trace_models.SpanType_Client:
+		s.Kind = tracepb.Span_SPAN_KIND_CLIENT
+		s.Attributes = append(s.Attributes,
+			otlphttp.String(otlpCallTypeKey, span.CallServiceType),
+			otlphttp.String(otlpCallServiceKey, span.CallService),
+			otlphttp.String(otlpCallResourceKey, span.CallResource),
+		)
+	default:
+		s.Kind = tracepb.Span_SPAN_KIND_INTERNAL
+	}
+	for k, v := range span.ParamInt {
+		s.Attributes = append(s.Attributes, otlphttp.Int(k, v))
+	}
+	for k, v := range span.ParamFloat {
+		s.Attributes = append(s.Attributes, otlphttp.Double(k, v))
+	}
+	if span.Status != 0 {
+		s.Status.Code = tracepb.Status_STATUS_CODE_ERROR
+		s.Attributes = append(s.Attributes, otlphttp.Int(otlpStatusKey, span.Status))
+	}
+	for _, errorInfo := range span.ErrorInfoList {
+		if errorInfo == nil {
+			continue
+		}
+		attrs := otlphttp.StringAttributes(errorInfo.ErrorTags)
+		attrs = append(attrs,
+			otlphttp.String(otlpErrorKindKey, errorInfo.ErrorKind.String()),
+			otlphttp.String(otlpExceptionMessage, errorInfo.ErrorMessage),
+		)
+		if len(errorInfo.ErrorStack) > 0 {
+			attrs = append(attrs, otlphttp.String(otlpExceptionStack, strings.Join(errorInfo.ErrorStack, "\n")))
+		}
+		s.Events = append(s.Events, &tracepb.Span_Event{
+			TimeUnixNano: uint64(errorInfo.ErrorOccurTime) * 1e6,
+			Name:         otlpErrorEventName,
+			Attributes:   attrs,
+		})
+	}
+	for _, event := range span.Events {
+		if event == nil {
+			continue
+		}
+		s.Events = append(s.Events, &tracepb.Span_Event{
+			TimeUnixNano: uint64(event.TimeMicrosecond) * 1e3,
+			Name:         event.Name,
+			Attributes:   otlphttp.StringAttributes(event.Attributes),
+		})
+	}
+	for _, link := range span.Links {
+		if link == nil {
+			continue
+		}
+		s.Links = append(s.Links, &tracepb.Span_Link{
+			TraceId:    otlphttp.TraceID(link.TraceId),
+			SpanId:     otlphttp.SpanID(link.SpanId),
+			Attributes: otlphttp.StringAttributes(link.Attributes),
+		})
+	}
+	return s
+}
@@ -0,0 +1,63 @@
+package trace_sender
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/otlphttp"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
+	collectortracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestOTLPTraceSender(t *testing.T) {
+	received := make(chan *collectortracepb.ExportTraceServiceRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		req := &collectortracepb.ExportTraceServiceRequest{}
+		assert.Nil(t, proto.Unmarshal(body, req))
+		received <- req
+	}))
+	defer server.Close()
+
+	in := make(chan *trace_models.Trace, 1)
+	s := NewOTLPTraceSender(otlphttp.Config{Endpoint: server.URL, DisableGzip: true}, in, nil)
+	s.Start()
+	in <- &trace_models.Trace{
+		ServiceType: "http",
+		Service:     "svc",
+		TraceId:     "2023010112000000a1b2c3d4e5f60718",
+		Spans: []*trace_models.Span{{
+			SpanId:               "20230101120000001122334455667788",
+			OperationName:        "GET /",
+			SpanType:             trace_models.SpanType_Server,
+			StartTimeMicrosecond: 1000,
+			DurationMicroseconds: 10,
+			Status:               1,
+			ErrorInfoList:        []*trace_models.ErrorInfo{{ErrorMessage: "err"}},
+			Events:               []*trace_models.SpanEvent{{Name: "retry", TimeMicrosecond: 1005}},
+			Links:                []*trace_models.SpanLink{{TraceId: "2023010112000000a1b2c3d4e5f60719", SpanId: "20230101120000001122334455667789"}},
+		}},
+	}
+	close(in)
+	s.WaitStop()
+
+	req := <-received
+	span := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "GET /", span.Name)
+	assert.Equal(t, tracepb.Span_SPAN_KIND_SERVER, span.Kind)
+	assert.Equal(t, uint64(1000000), span.StartTimeUnixNano)
+	assert.Equal(t, uint64(1010000), span.EndTimeUnixNano)
+	assert.Equal(t, 8, len(span.SpanId))
+	assert.Equal(t, 0, len(span.ParentSpanId))
+	assert.Equal(t, tracepb.Status_STATUS_CODE_ERROR, span.Status.Code)
+	assert.Equal(t, "exception", span.Events[0].Name)
+	assert.Equal(t, "retry", span.Events[1].Name)
+	assert.Equal(t, uint64(1005000), span.Events[1].TimeUnixNano)
+	assert.Equal(t, 16, len(span.Links[0].TraceId))
+	assert.Equal(t, 8, len(span.Links[0].SpanId))
+}
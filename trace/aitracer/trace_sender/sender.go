@@ -1,13 +1,18 @@
 package trace_sender
 
 import (
+	"context"
 	"encoding/binary"
 	"sync"
 	"time"
 
+	"github.com/volcengine/apminsight-server-sdk-go/internal/flush"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/otlphttp"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/agentless_adapter"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/sendworker"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/service_register/register_utils"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/utils"
@@ -16,6 +21,10 @@ import (
 const (
 	maxBatchBytes       = 16384    // should remain 16KB because old version of server-agent still use 16KB buffer
 	streamMaxBatchBytes = 64 << 10 //64KB
+
+	collectorPath = "/server_collect/trace_collect"
+
+	maxOverflowReplay = 1024 // traces replayed from overflow on each flush, so that channel is still consumed with backlog
 )
 
 func NewTraceSender(sock, streamSock string, in chan *trace_models.Trace, l logger.Logger) *TraceSender {
@@ -39,6 +48,12 @@ type TraceSender struct {
 	tags []byte
 
 	w sendworker.SendWorker
+
+	otlp *otlphttp.Client // export by OTLP/HTTP instead of server-agent if set
+
+	overflow *sendworker.SpillBuffer // size-prefixed traces dropped by producer, drained on each flush
+
+	flushReqs *flush.Requests
 }
 
 func newDatagramTraceSender(sock string, in chan *trace_models.Trace, l logger.Logger) *TraceSender {
@@ -50,8 +65,9 @@ func newDatagramTraceSender(sock string, in chan *trace_models.Trace, l logger.L
 	}
 	l.Info("newDatagramTraceSender success")
 	return &TraceSender{
-		logger: l,
-		in:     in,
+		logger:    l,
+		in:        in,
+		flushReqs: flush.NewRequests(),
 
 		bufferMaxSize: maxBatchBytes, //16KB
 		offset:        0,             // do not need
@@ -73,8 +89,9 @@ func newStreamTraceSender(sock string, in chan *trace_models.Trace, l logger.Log
 
 	tagsBytes := sendworker.FormatMap(map[string]string{"instanceID": register_utils.GetInstanceID()})
 	return &TraceSender{
-		logger: l,
-		in:     in,
+		logger:    l,
+		in:        in,
+		flushReqs: flush.NewRequests(),
 
 		bufferMaxSize: streamMaxBatchBytes,                   //64KB
 		offset:        sendworker.GetPrefixLen(1, tagsBytes), // preallocate prefix
@@ -85,13 +102,59 @@ func newStreamTraceSender(sock string, in chan *trace_models.Trace, l logger.Log
 	}
 }
 
+// NewHTTPTraceSender sends traces to collector directly by http, which is useful where server-agent is not installed.
+// Traces of a batch are wrapped in TraceCollect with hostname
+func NewHTTPTraceSender(schema, host string, timeout time.Duration, in chan *trace_models.Trace, l logger.Logger) *TraceSender {
+	if host == "" {
+		panic("host is empty")
+	}
+	if l == nil {
+		l = &logger.NoopLogger{}
+	}
+	l.Info("newHTTPTraceSender success")
+	return &TraceSender{
+		logger:    l,
+		in:        in,
+		flushReqs: flush.NewRequests(),
+
+		bufferMaxSize: streamMaxBatchBytes, //64KB
+		offset:        0,                   // do not need
+		flushInterval: 5 * time.Second,
+		tags:          nil, // hostname is sent in TraceCollect instead
+
+		w: sendworker.NewHTTPWorker("trace", schema, host, collectorPath, timeout, wrapTraceCollect, l),
+	}
+}
+
+func wrapTraceCollect(traces [][]byte) ([]byte, error) {
+	tc := &trace_models.TraceCollect{
+		Traces:   traces,
+		Hostname: agentless_adapter.GetHostname(),
+	}
+	return tc.Marshal()
+}
+
+// SetSpillBuffer enables disk-backed buffering. Batches failed to send to server-agent are spilled to spill,
+// and traces pushed to overflow by producer when channel is full are sent in following flushes.
+// Either can be nil. It should be called before Start
+func (s *TraceSender) SetSpillBuffer(spill, overflow *sendworker.SpillBuffer) {
+	if w, ok := s.w.(interface{ SetSpillBuffer(*sendworker.SpillBuffer) }); ok && spill != nil {
+		w.SetSpillBuffer(spill)
+	}
+	s.overflow = overflow
+}
+
 func (s *TraceSender) Start() {
 	s.wg.Add(1)
 	go func() {
 		defer func() {
 			s.wg.Done()
 		}()
-		s.sendLoop()
+		if s.otlp != nil {
+			s.otlpSendLoop()
+		} else {
+			s.sendLoop()
+		}
 	}()
 }
 
@@ -99,6 +162,27 @@ func (s *TraceSender) WaitStop() {
 	s.wg.Wait()
 }
 
+// Flush sends traces queued in channel and buffered in batch, and waits for them to be sent until ctx is done.
+// Traces shared channel with other senders may be sent by them. It should be called after Start
+func (s *TraceSender) Flush(ctx context.Context) error {
+	return s.flushReqs.Flush(ctx, 1)
+}
+
+// drainIn receives traces queued in channel without blocking
+func (s *TraceSender) drainIn(add func(item *trace_models.Trace)) {
+	for {
+		select {
+		case item, ok := <-s.in:
+			if !ok {
+				return
+			}
+			add(item)
+		default:
+			return
+		}
+	}
+}
+
 func (s *TraceSender) sendLoop() {
 	defer func() {
 		s.w.CloseConn()
@@ -116,6 +200,7 @@ func (s *TraceSender) sendLoop() {
 				s.w.BatchSend(batchTrace, s.tags)
 				batchTrace = batchTrace[:s.offset]
 			}
+			batchTrace = s.drainOverflow(batchTrace)
 		case item, ok := <-s.in:
 			if !ok {
 				if len(batchTrace) > s.offset {
@@ -123,38 +208,79 @@ func (s *TraceSender) sendLoop() {
 				}
 				return
 			}
-			if item == nil {
-				continue
-			}
-			size := item.Size()
-			sizePrefixData := make([]byte, 4+size)
-			binary.LittleEndian.PutUint32(sizePrefixData[0:4], uint32(size)) // Is's ok to cast a positive int to uint32
-			_, err := item.MarshalTo(sizePrefixData[4 : 4+size])
-			if err != nil {
-				s.logger.Error("send trace marshal err %v", err)
-				continue
+			batchTrace = s.appendTrace(batchTrace, item)
+		case b := <-s.flushReqs.C:
+			s.drainIn(func(item *trace_models.Trace) {
+				batchTrace = s.appendTrace(batchTrace, item)
+			})
+			if len(batchTrace) > s.offset {
+				s.w.BatchSend(batchTrace, s.tags)
+				batchTrace = batchTrace[:s.offset]
 			}
+			batchTrace = s.drainOverflow(batchTrace)
+			b.Done()
+		}
+	}
+}
+
+// appendTrace appends size-prefixed item to batchTrace, and sends batchTrace if it is full
+func (s *TraceSender) appendTrace(batchTrace []byte, item *trace_models.Trace) []byte {
+	if item == nil {
+		return batchTrace
+	}
+	size := item.Size()
+	sizePrefixData := make([]byte, 4+size)
+	binary.LittleEndian.PutUint32(sizePrefixData[0:4], uint32(size)) // Is's ok to cast a positive int to uint32
+	_, err := item.MarshalTo(sizePrefixData[4 : 4+size])
+	if err != nil {
+		s.logger.Error("send trace marshal err %v", err)
+		selfstats.Trace.AddEncodeError(1)
+		return batchTrace
+	}
 
-			s.logger.Debug("send trace %+v, len=%d", item, size)
+	s.logger.Debug("send trace %+v, len=%d", item, size)
 
-			if len(batchTrace)+len(sizePrefixData) <= s.bufferMaxSize+s.offset {
-				batchTrace = append(batchTrace, sizePrefixData...)
+	if len(batchTrace)+len(sizePrefixData) <= s.bufferMaxSize+s.offset {
+		batchTrace = append(batchTrace, sizePrefixData...)
+	} else {
+		if len(sizePrefixData) > s.bufferMaxSize+s.offset { // avoid grow batchTrace
+			var tmpBuf []byte
+			if s.offset > 0 {
+				tmpBuf = make([]byte, s.offset, s.offset+len(sizePrefixData)) // preAlloc. very low chance to enter this condition
+				tmpBuf = append(tmpBuf, sizePrefixData...)
 			} else {
-				if len(sizePrefixData) > s.bufferMaxSize+s.offset { // avoid grow batchTrace
-					var tmpBuf []byte
-					if s.offset > 0 {
-						tmpBuf = make([]byte, s.offset, s.offset+len(sizePrefixData)) // preAlloc. very low chance to enter this condition
-						tmpBuf = append(tmpBuf, sizePrefixData...)
-					} else {
-						tmpBuf = sizePrefixData
-					}
-					s.w.BatchSend(tmpBuf, s.tags) // this will lead agent to read truncated data. should discard directly here?
-				} else {
-					s.w.BatchSend(batchTrace, s.tags)
-					batchTrace = batchTrace[:s.offset]
-					batchTrace = append(batchTrace, sizePrefixData...)
-				}
+				tmpBuf = sizePrefixData
 			}
+			s.w.BatchSend(tmpBuf, s.tags) // this will lead agent to read truncated data. should discard directly here?
+		} else {
+			s.w.BatchSend(batchTrace, s.tags)
+			batchTrace = batchTrace[:s.offset]
+			batchTrace = append(batchTrace, sizePrefixData...)
 		}
 	}
+	return batchTrace
+}
+
+// drainOverflow sends at most maxOverflowReplay traces in overflow by batches. batchTrace should be empty
+func (s *TraceSender) drainOverflow(batchTrace []byte) []byte {
+	if s.overflow == nil {
+		return batchTrace
+	}
+	records, _ := s.overflow.Take(maxOverflowReplay)
+	for _, sizePrefixData := range records {
+		if len(sizePrefixData) > s.bufferMaxSize { // too large to fit in any batch, discard
+			selfstats.Trace.AddDropped(1)
+			continue
+		}
+		if len(batchTrace)+len(sizePrefixData) > s.bufferMaxSize+s.offset {
+			s.w.BatchSend(batchTrace, s.tags)
+			batchTrace = batchTrace[:s.offset]
+		}
+		batchTrace = append(batchTrace, sizePrefixData...)
+	}
+	if len(batchTrace) > s.offset {
+		s.w.BatchSend(batchTrace, s.tags)
+		batchTrace = batchTrace[:s.offset]
+	}
+	return batchTrace
 }
@@ -0,0 +1,46 @@
+package trace_sender
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/sendworker"
+)
+
+type countingWorker struct {
+	traces int
+}
+
+func (w *countingWorker) BatchSend(data []byte, _ []byte) {
+	w.traces += sendworker.CountSizePrefixed(data)
+}
+
+func (w *countingWorker) CloseConn() {}
+
+func TestDrainOverflow(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overflow")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	overflow, err := sendworker.NewSpillBuffer(sendworker.SpillConfig{Dir: dir})
+	assert.Nil(t, err)
+	defer overflow.Close()
+
+	record := make([]byte, 4+100)
+	binary.LittleEndian.PutUint32(record[0:4], 100)
+	for i := 0; i < maxOverflowReplay+1; i++ {
+		assert.Nil(t, overflow.Push(record))
+	}
+
+	w := &countingWorker{}
+	s := &TraceSender{bufferMaxSize: maxBatchBytes, w: w, overflow: overflow}
+	batchTrace := s.drainOverflow(nil)
+	assert.Empty(t, batchTrace)
+	assert.Equal(t, maxOverflowReplay, w.traces) // bounded by each flush
+	assert.False(t, overflow.Empty())
+	s.drainOverflow(batchTrace)
+	assert.Equal(t, maxOverflowReplay+1, w.traces)
+	assert.True(t, overflow.Empty())
+}
@@ -59,7 +59,7 @@ func (x *SpanType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (SpanType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_internal_trace_04883c2397d91b0d, []int{0}
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{0}
 }
 
 type ErrorType int32
@@ -113,7 +113,7 @@ func (x *ErrorType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (ErrorType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_internal_trace_04883c2397d91b0d, []int{1}
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{1}
 }
 
 type ErrorTag int32
@@ -149,7 +149,7 @@ func (x *ErrorTag) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (ErrorTag) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_internal_trace_04883c2397d91b0d, []int{2}
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{2}
 }
 
 type TraceCollect struct {
@@ -161,7 +161,7 @@ func (m *TraceCollect) Reset()         { *m = TraceCollect{} }
 func (m *TraceCollect) String() string { return proto.CompactTextString(m) }
 func (*TraceCollect) ProtoMessage()    {}
 func (*TraceCollect) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_trace_04883c2397d91b0d, []int{0}
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{0}
 }
 func (m *TraceCollect) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -217,13 +217,16 @@ type Trace struct {
 	AppId            string  `protobuf:"bytes,64,opt,name=app_id,json=appId" json:"app_id"`
 	Hostname         string  `protobuf:"bytes,65,opt,name=hostname" json:"hostname"`
 	ApiTimeSeconds   int64   `protobuf:"varint,66,opt,name=api_time_seconds,json=apiTimeSeconds" json:"api_time_seconds"`
+	SampleFlags      int32   `protobuf:"varint,67,opt,name=sample_flags,json=sampleFlags" json:"sample_flags"`
+	SampleWeight     int32   `protobuf:"varint,68,opt,name=sample_weight,json=sampleWeight" json:"sample_weight"`
+	TailSampled      bool    `protobuf:"varint,69,opt,name=tail_sampled,json=tailSampled" json:"tail_sampled"`
 }
 
 func (m *Trace) Reset()         { *m = Trace{} }
 func (m *Trace) String() string { return proto.CompactTextString(m) }
 func (*Trace) ProtoMessage()    {}
 func (*Trace) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_trace_04883c2397d91b0d, []int{1}
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{1}
 }
 func (m *Trace) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -336,6 +339,27 @@ func (m *Trace) GetApiTimeSeconds() int64 {
 	return 0
 }
 
+func (m *Trace) GetSampleFlags() int32 {
+	if m != nil {
+		return m.SampleFlags
+	}
+	return 0
+}
+
+func (m *Trace) GetSampleWeight() int32 {
+	if m != nil {
+		return m.SampleWeight
+	}
+	return 0
+}
+
+func (m *Trace) GetTailSampled() bool {
+	if m != nil {
+		return m.TailSampled
+	}
+	return false
+}
+
 type Span struct {
 	SpanId               string             `protobuf:"bytes,1,req,name=span_id,json=spanId" json:"span_id"`
 	ParentSpanId         string             `protobuf:"bytes,2,req,name=parent_span_id,json=parentSpanId" json:"parent_span_id"`
@@ -354,13 +378,15 @@ type Span struct {
 	CallService          string             `protobuf:"bytes,61,opt,name=call_service,json=callService" json:"call_service"`
 	CallResource         string             `protobuf:"bytes,62,opt,name=call_resource,json=callResource" json:"call_resource"`
 	ErrorInfoList        []*ErrorInfo       `protobuf:"bytes,70,rep,name=error_info_list,json=errorInfoList" json:"error_info_list,omitempty"`
+	Events               []*SpanEvent       `protobuf:"bytes,71,rep,name=events" json:"events,omitempty"`
+	Links                []*SpanLink        `protobuf:"bytes,72,rep,name=links" json:"links,omitempty"`
 }
 
 func (m *Span) Reset()         { *m = Span{} }
 func (m *Span) String() string { return proto.CompactTextString(m) }
 func (*Span) ProtoMessage()    {}
 func (*Span) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_trace_04883c2397d91b0d, []int{2}
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{2}
 }
 func (m *Span) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -508,6 +534,20 @@ func (m *Span) GetErrorInfoList() []*ErrorInfo {
 	return nil
 }
 
+func (m *Span) GetEvents() []*SpanEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *Span) GetLinks() []*SpanLink {
+	if m != nil {
+		return m.Links
+	}
+	return nil
+}
+
 type ErrorInfo struct {
 	ErrorKind      ErrorType         `protobuf:"varint,1,opt,name=error_kind,json=errorKind,enum=trace_models.ErrorType" json:"error_kind"`
 	ErrorMessage   string            `protobuf:"bytes,2,opt,name=error_message,json=errorMessage" json:"error_message"`
@@ -520,7 +560,7 @@ func (m *ErrorInfo) Reset()         { *m = ErrorInfo{} }
 func (m *ErrorInfo) String() string { return proto.CompactTextString(m) }
 func (*ErrorInfo) ProtoMessage()    {}
 func (*ErrorInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_internal_trace_04883c2397d91b0d, []int{3}
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{3}
 }
 func (m *ErrorInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -584,6 +624,126 @@ func (m *ErrorInfo) GetErrorTags() map[string]string {
 	return nil
 }
 
+type SpanEvent struct {
+	Name            string            `protobuf:"bytes,1,opt,name=name" json:"name"`
+	TimeMicrosecond int64             `protobuf:"varint,2,opt,name=time_microsecond,json=timeMicrosecond" json:"time_microsecond"`
+	Attributes      map[string]string `protobuf:"bytes,3,rep,name=attributes" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SpanEvent) Reset()         { *m = SpanEvent{} }
+func (m *SpanEvent) String() string { return proto.CompactTextString(m) }
+func (*SpanEvent) ProtoMessage()    {}
+func (*SpanEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{4}
+}
+func (m *SpanEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpanEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpanEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SpanEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpanEvent.Merge(dst, src)
+}
+func (m *SpanEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpanEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpanEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpanEvent proto.InternalMessageInfo
+
+func (m *SpanEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *SpanEvent) GetTimeMicrosecond() int64 {
+	if m != nil {
+		return m.TimeMicrosecond
+	}
+	return 0
+}
+
+func (m *SpanEvent) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+type SpanLink struct {
+	TraceId    string            `protobuf:"bytes,1,opt,name=trace_id,json=traceId" json:"trace_id"`
+	SpanId     string            `protobuf:"bytes,2,opt,name=span_id,json=spanId" json:"span_id"`
+	Attributes map[string]string `protobuf:"bytes,3,rep,name=attributes" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *SpanLink) Reset()         { *m = SpanLink{} }
+func (m *SpanLink) String() string { return proto.CompactTextString(m) }
+func (*SpanLink) ProtoMessage()    {}
+func (*SpanLink) Descriptor() ([]byte, []int) {
+	return fileDescriptor_internal_trace_df2579634d3ea5ed, []int{5}
+}
+func (m *SpanLink) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpanLink) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpanLink.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *SpanLink) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpanLink.Merge(dst, src)
+}
+func (m *SpanLink) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpanLink) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpanLink.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpanLink proto.InternalMessageInfo
+
+func (m *SpanLink) GetTraceId() string {
+	if m != nil {
+		return m.TraceId
+	}
+	return ""
+}
+
+func (m *SpanLink) GetSpanId() string {
+	if m != nil {
+		return m.SpanId
+	}
+	return ""
+}
+
+func (m *SpanLink) GetAttributes() map[string]string {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*TraceCollect)(nil), "trace_models.TraceCollect")
 	proto.RegisterType((*Trace)(nil), "trace_models.Trace")
@@ -593,6 +753,10 @@ func init() {
 	proto.RegisterMapType((map[string]string)(nil), "trace_models.Span.ParamStringEntry")
 	proto.RegisterType((*ErrorInfo)(nil), "trace_models.ErrorInfo")
 	proto.RegisterMapType((map[string]string)(nil), "trace_models.ErrorInfo.ErrorTagsEntry")
+	proto.RegisterType((*SpanEvent)(nil), "trace_models.SpanEvent")
+	proto.RegisterMapType((map[string]string)(nil), "trace_models.SpanEvent.AttributesEntry")
+	proto.RegisterType((*SpanLink)(nil), "trace_models.SpanLink")
+	proto.RegisterMapType((map[string]string)(nil), "trace_models.SpanLink.AttributesEntry")
 	proto.RegisterEnum("trace_models.SpanType", SpanType_name, SpanType_value)
 	proto.RegisterEnum("trace_models.ErrorType", ErrorType_name, ErrorType_value)
 	proto.RegisterEnum("trace_models.ErrorTag", ErrorTag_name, ErrorTag_value)
@@ -701,6 +865,26 @@ func (m *Trace) MarshalTo(dAtA []byte) (int, error) {
 	dAtA[i] = 0x4
 	i++
 	i = encodeVarintInternalTrace(dAtA, i, uint64(m.ApiTimeSeconds))
+	dAtA[i] = 0x98
+	i++
+	dAtA[i] = 0x4
+	i++
+	i = encodeVarintInternalTrace(dAtA, i, uint64(m.SampleFlags))
+	dAtA[i] = 0xa0
+	i++
+	dAtA[i] = 0x4
+	i++
+	i = encodeVarintInternalTrace(dAtA, i, uint64(m.SampleWeight))
+	dAtA[i] = 0xa8
+	i++
+	dAtA[i] = 0x4
+	i++
+	if m.TailSampled {
+		dAtA[i] = 1
+	} else {
+		dAtA[i] = 0
+	}
+	i++
 	return i, nil
 }
 
@@ -843,6 +1027,34 @@ func (m *Span) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if len(m.Events) > 0 {
+		for _, msg := range m.Events {
+			dAtA[i] = 0xba
+			i++
+			dAtA[i] = 0x4
+			i++
+			i = encodeVarintInternalTrace(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Links) > 0 {
+		for _, msg := range m.Links {
+			dAtA[i] = 0xc2
+			i++
+			dAtA[i] = 0x4
+			i++
+			i = encodeVarintInternalTrace(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -908,6 +1120,91 @@ func (m *ErrorInfo) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *SpanEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SpanEvent) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintInternalTrace(dAtA, i, uint64(len(m.Name)))
+	i += copy(dAtA[i:], m.Name)
+	dAtA[i] = 0x10
+	i++
+	i = encodeVarintInternalTrace(dAtA, i, uint64(m.TimeMicrosecond))
+	if len(m.Attributes) > 0 {
+		for k, _ := range m.Attributes {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Attributes[k]
+			mapSize := 1 + len(k) + sovInternalTrace(uint64(len(k))) + 1 + len(v) + sovInternalTrace(uint64(len(v)))
+			i = encodeVarintInternalTrace(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintInternalTrace(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintInternalTrace(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	return i, nil
+}
+
+func (m *SpanLink) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SpanLink) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintInternalTrace(dAtA, i, uint64(len(m.TraceId)))
+	i += copy(dAtA[i:], m.TraceId)
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintInternalTrace(dAtA, i, uint64(len(m.SpanId)))
+	i += copy(dAtA[i:], m.SpanId)
+	if len(m.Attributes) > 0 {
+		for k, _ := range m.Attributes {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Attributes[k]
+			mapSize := 1 + len(k) + sovInternalTrace(uint64(len(k))) + 1 + len(v) + sovInternalTrace(uint64(len(v)))
+			i = encodeVarintInternalTrace(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintInternalTrace(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintInternalTrace(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	return i, nil
+}
+
 func encodeVarintInternalTrace(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -965,6 +1262,9 @@ func (m *Trace) Size() (n int) {
 	l = len(m.Hostname)
 	n += 2 + l + sovInternalTrace(uint64(l))
 	n += 2 + sovInternalTrace(uint64(m.ApiTimeSeconds))
+	n += 2 + sovInternalTrace(uint64(m.SampleFlags))
+	n += 2 + sovInternalTrace(uint64(m.SampleWeight))
+	n += 3
 	return n
 }
 
@@ -1024,6 +1324,18 @@ func (m *Span) Size() (n int) {
 			n += 2 + l + sovInternalTrace(uint64(l))
 		}
 	}
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 2 + l + sovInternalTrace(uint64(l))
+		}
+	}
+	if len(m.Links) > 0 {
+		for _, e := range m.Links {
+			l = e.Size()
+			n += 2 + l + sovInternalTrace(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1054,6 +1366,47 @@ func (m *ErrorInfo) Size() (n int) {
 	return n
 }
 
+func (m *SpanEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	n += 1 + l + sovInternalTrace(uint64(l))
+	n += 1 + sovInternalTrace(uint64(m.TimeMicrosecond))
+	if len(m.Attributes) > 0 {
+		for k, v := range m.Attributes {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovInternalTrace(uint64(len(k))) + 1 + len(v) + sovInternalTrace(uint64(len(v)))
+			n += mapEntrySize + 1 + sovInternalTrace(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *SpanLink) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TraceId)
+	n += 1 + l + sovInternalTrace(uint64(l))
+	l = len(m.SpanId)
+	n += 1 + l + sovInternalTrace(uint64(l))
+	if len(m.Attributes) > 0 {
+		for k, v := range m.Attributes {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovInternalTrace(uint64(len(k))) + 1 + len(v) + sovInternalTrace(uint64(len(v)))
+			n += mapEntrySize + 1 + sovInternalTrace(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
 func sovInternalTrace(x uint64) (n int) {
 	for {
 		n++
@@ -1534,20 +1887,78 @@ func (m *Trace) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		default:
-			iNdEx = preIndex
-			skippy, err := skipInternalTrace(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthInternalTrace
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+		case 67:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleFlags", wireType)
 			}
-			iNdEx += skippy
-		}
+			m.SampleFlags = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleFlags |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 68:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleWeight", wireType)
+			}
+			m.SampleWeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleWeight |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 69:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TailSampled", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.TailSampled = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternalTrace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
 	if hasFields[0]&uint64(0x00000001) == 0 {
 		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("service_type")
@@ -2279,6 +2690,68 @@ func (m *Span) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 71:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Events = append(m.Events, &SpanEvent{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 72:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Links", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Links = append(m.Links, &SpanLink{})
+			if err := m.Links[len(m.Links)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipInternalTrace(dAtA[iNdEx:])
@@ -2591,6 +3064,448 @@ func (m *ErrorInfo) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SpanEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternalTrace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SpanEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SpanEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TimeMicrosecond", wireType)
+			}
+			m.TimeMicrosecond = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TimeMicrosecond |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Attributes == nil {
+				m.Attributes = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowInternalTrace
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowInternalTrace
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthInternalTrace
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowInternalTrace
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthInternalTrace
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipInternalTrace(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthInternalTrace
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Attributes[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternalTrace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SpanLink) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowInternalTrace
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SpanLink: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SpanLink: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TraceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TraceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SpanId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Attributes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowInternalTrace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Attributes == nil {
+				m.Attributes = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowInternalTrace
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowInternalTrace
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthInternalTrace
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowInternalTrace
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthInternalTrace
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipInternalTrace(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthInternalTrace
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Attributes[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipInternalTrace(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthInternalTrace
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipInternalTrace(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2697,74 +3612,85 @@ var (
 )
 
 func init() {
-	proto.RegisterFile("internal_trace.proto", fileDescriptor_internal_trace_04883c2397d91b0d)
-}
-
-var fileDescriptor_internal_trace_04883c2397d91b0d = []byte{
-	// 1028 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x4d, 0x6f, 0x1b, 0x37,
-	0x13, 0xd6, 0x4a, 0xd6, 0xd7, 0x48, 0x96, 0x65, 0xc2, 0xf6, 0x4b, 0xf8, 0x6d, 0x65, 0x41, 0x45,
-	0x53, 0xd5, 0x05, 0x84, 0xc2, 0x87, 0xa2, 0x09, 0x9c, 0x7e, 0xd8, 0xb5, 0x6b, 0xb5, 0x75, 0x1a,
-	0x48, 0xee, 0x79, 0xc1, 0xec, 0xd2, 0x0e, 0xe1, 0x5d, 0x92, 0x59, 0x52, 0x46, 0x7c, 0xeb, 0x4f,
-	0xe8, 0xad, 0xff, 0xa6, 0xe7, 0x1c, 0x73, 0x4b, 0x4f, 0x45, 0x61, 0xff, 0x91, 0x82, 0xe4, 0xae,
-	0x4c, 0x59, 0x35, 0x10, 0xf4, 0xb6, 0x33, 0xcf, 0x33, 0xcf, 0x0c, 0x67, 0xc8, 0x91, 0x60, 0x83,
-	0x71, 0x4d, 0x33, 0x4e, 0x92, 0x50, 0x67, 0x24, 0xa2, 0x23, 0x99, 0x09, 0x2d, 0x50, 0xdb, 0x1a,
-	0x61, 0x2a, 0x62, 0x9a, 0xa8, 0xc1, 0x09, 0xb4, 0xcf, 0x8c, 0x7d, 0x28, 0x92, 0x84, 0x46, 0x1a,
-	0x6d, 0x41, 0xcd, 0xe2, 0x0a, 0x07, 0xfd, 0xca, 0xb0, 0x3d, 0xc9, 0x2d, 0xd4, 0x87, 0xc6, 0x4b,
-	0xa1, 0x34, 0x27, 0x29, 0xc5, 0xe5, 0x7e, 0x79, 0xd8, 0x3c, 0x58, 0x79, 0xf3, 0xd7, 0x4e, 0x69,
-	0x32, 0xf7, 0x0e, 0xfe, 0xa8, 0x40, 0xd5, 0x4a, 0xa1, 0x4f, 0xa0, 0xad, 0x68, 0x76, 0xc5, 0x22,
-	0x1a, 0xea, 0x6b, 0x49, 0x71, 0xe0, 0xf1, 0x5b, 0x39, 0x72, 0x76, 0x2d, 0x29, 0xea, 0x41, 0x3d,
-	0x37, 0x17, 0x34, 0x0b, 0x27, 0xda, 0x81, 0x86, 0x2b, 0x96, 0xc5, 0xb8, 0xe2, 0x13, 0xac, 0x77,
-	0x1c, 0xa3, 0x2d, 0xa8, 0x50, 0x7e, 0x85, 0x57, 0x3c, 0xcc, 0x38, 0xd0, 0x10, 0xaa, 0x4a, 0x12,
-	0xae, 0x70, 0xb5, 0x5f, 0x19, 0xb6, 0xf6, 0xd0, 0xc8, 0x3f, 0xf3, 0x68, 0x2a, 0x09, 0x9f, 0x38,
-	0x82, 0xa9, 0x35, 0x12, 0x5c, 0x13, 0xc6, 0x69, 0x66, 0xd2, 0xd4, 0xfa, 0xc1, 0x5d, 0xad, 0x73,
-	0xc4, 0xa5, 0x92, 0x2c, 0xc6, 0xf5, 0x7e, 0x30, 0xac, 0x14, 0xa9, 0x24, 0x8b, 0xd1, 0x1e, 0x20,
-	0x99, 0x89, 0x88, 0x2a, 0x15, 0x2a, 0x4d, 0x32, 0x1d, 0x6a, 0x96, 0x52, 0xdc, 0xf0, 0x68, 0xdd,
-	0x1c, 0x9f, 0x1a, 0xf8, 0x8c, 0xa5, 0x14, 0x7d, 0x0c, 0x2d, 0xc6, 0x95, 0x26, 0xdc, 0x1d, 0xad,
-	0xe9, 0xe5, 0x84, 0x02, 0x18, 0xc7, 0xe8, 0xff, 0x50, 0x23, 0x52, 0x1a, 0xc6, 0x37, 0x1e, 0xa3,
-	0x4a, 0xa4, 0x1c, 0xc7, 0x0b, 0x03, 0xf9, 0xd6, 0x83, 0xe7, 0x5e, 0x34, 0x82, 0x2e, 0x91, 0xcc,
-	0xd6, 0x13, 0x2a, 0x1a, 0x09, 0x1e, 0x2b, 0x7c, 0xe0, 0xd5, 0xd5, 0x21, 0x92, 0x99, 0x72, 0xa6,
-	0x0e, 0x1b, 0xbc, 0x6b, 0xc0, 0x8a, 0x69, 0x0d, 0xfa, 0x10, 0xea, 0xa6, 0x39, 0x26, 0xb1, 0x3f,
-	0xba, 0x9a, 0x71, 0x8e, 0x63, 0xb4, 0x0b, 0x1d, 0x49, 0x32, 0xca, 0x75, 0x58, 0xb0, 0xfc, 0xe1,
-	0xb5, 0x1d, 0x36, 0x75, 0xdc, 0xcf, 0xa0, 0x23, 0x24, 0xcd, 0x88, 0x66, 0x82, 0x87, 0xb6, 0x56,
-	0x7f, 0x8e, 0xab, 0x73, 0xec, 0x99, 0x29, 0xf8, 0x09, 0x6c, 0xdd, 0xb5, 0x30, 0x4c, 0x59, 0x92,
-	0x30, 0x57, 0xb7, 0x1d, 0x70, 0x51, 0xf6, 0x86, 0x2a, 0xfa, 0x78, 0x7a, 0xc7, 0x40, 0x5f, 0xc0,
-	0x06, 0xe5, 0xf1, 0x72, 0x64, 0xd5, 0x8b, 0x44, 0x94, 0xc7, 0xf7, 0xe3, 0x1e, 0xc3, 0x66, 0x3c,
-	0xcb, 0xeb, 0x4b, 0x59, 0x94, 0x89, 0xa2, 0x53, 0x35, 0x3f, 0x65, 0x41, 0x39, 0xf5, 0x18, 0xe8,
-	0x03, 0xa8, 0x29, 0x4d, 0xf4, 0x4c, 0xe1, 0xba, 0xc7, 0xcd, 0x7d, 0xe8, 0x31, 0x34, 0x6d, 0x7b,
-	0xec, 0x0b, 0x68, 0xf4, 0xcb, 0xc3, 0xce, 0xde, 0xd6, 0xf2, 0x35, 0x34, 0xcf, 0xa0, 0x18, 0x9c,
-	0xca, 0xed, 0xa5, 0x3e, 0xcc, 0x73, 0xe2, 0xe6, 0x03, 0x7d, 0x98, 0x33, 0xd0, 0x53, 0x68, 0x4a,
-	0x92, 0x91, 0x34, 0x64, 0x5c, 0xe3, 0x0d, 0x7b, 0xfb, 0xfb, 0xcb, 0x69, 0x47, 0xcf, 0x0d, 0x67,
-	0xcc, 0xf5, 0x11, 0xd7, 0xd9, 0xf5, 0xa4, 0x21, 0x73, 0x13, 0x1d, 0x42, 0xcb, 0x85, 0x9f, 0x27,
-	0x82, 0x68, 0xbc, 0x69, 0x05, 0x06, 0x0f, 0x09, 0x1c, 0x1b, 0x92, 0x93, 0x00, 0x39, 0x77, 0xa0,
-	0x63, 0x68, 0x3b, 0x11, 0xa5, 0x33, 0xc6, 0x2f, 0xf0, 0x96, 0x55, 0xf9, 0xe8, 0x21, 0x95, 0xa9,
-	0x65, 0x39, 0x19, 0x97, 0xdd, 0x79, 0xcc, 0x15, 0xcf, 0xa8, 0x12, 0xb3, 0x2c, 0xa2, 0x78, 0xe8,
-	0x5f, 0xf1, 0xc2, 0x8b, 0x3e, 0x87, 0xf5, 0x88, 0x24, 0x49, 0xb8, 0xb0, 0x6e, 0xf6, 0x3d, 0xea,
-	0x9a, 0x81, 0xa7, 0xde, 0xca, 0x31, 0xef, 0xdd, 0x8b, 0xc0, 0x4f, 0x17, 0xde, 0xfb, 0x1d, 0x19,
-	0x7d, 0x0a, 0xab, 0x96, 0x38, 0xaf, 0xe0, 0x2b, 0x8f, 0x69, 0x35, 0x26, 0x45, 0x15, 0x5f, 0xc3,
-	0x1a, 0xcd, 0x32, 0x91, 0x85, 0x8c, 0x9f, 0x8b, 0x30, 0x61, 0x4a, 0xe3, 0x63, 0x7b, 0xe4, 0xff,
-	0x2d, 0x1e, 0xf9, 0xc8, 0x90, 0xc6, 0xfc, 0x5c, 0x4c, 0x56, 0x69, 0xf1, 0xf9, 0x13, 0x53, 0x7a,
-	0xfb, 0x7b, 0x58, 0x5d, 0x18, 0x88, 0x59, 0x36, 0x97, 0xf4, 0x1a, 0x07, 0x5e, 0x4a, 0xe3, 0x40,
-	0xdb, 0x50, 0xbd, 0x22, 0xc9, 0xcc, 0xac, 0xcb, 0xbb, 0x77, 0xec, 0x5c, 0x4f, 0xca, 0x5f, 0x06,
-	0xdb, 0x63, 0x58, 0xbb, 0x37, 0x98, 0xf7, 0x93, 0x0a, 0x96, 0xa5, 0x7e, 0x80, 0xee, 0xfd, 0xe9,
-	0xbc, 0x9f, 0x56, 0x73, 0x49, 0x6b, 0xf0, 0xae, 0x0c, 0xcd, 0xf9, 0xe1, 0xd1, 0x3e, 0x80, 0x6b,
-	0xd7, 0x25, 0xe3, 0xb1, 0x15, 0xeb, 0xfc, 0x6b, 0xa7, 0xbc, 0xb7, 0xd1, 0xb4, 0x01, 0x3f, 0x32,
-	0x1e, 0x9b, 0xb9, 0xb8, 0xe8, 0x94, 0x2a, 0x45, 0x2e, 0x16, 0x73, 0xb6, 0x2d, 0x74, 0xea, 0x10,
-	0xb4, 0x03, 0x2d, 0x47, 0x55, 0x9a, 0x44, 0x97, 0xb8, 0xd2, 0xaf, 0x0c, 0x9b, 0x13, 0x97, 0x7b,
-	0x6a, 0x3c, 0x66, 0x43, 0x3a, 0x82, 0x88, 0xa2, 0x59, 0xe6, 0x36, 0xf7, 0x8a, 0xbf, 0x21, 0x2d,
-	0xfa, 0xb3, 0x01, 0xed, 0xde, 0x3e, 0x2e, 0x2a, 0xd7, 0xe4, 0x42, 0xe1, 0x5f, 0xcb, 0x76, 0xc8,
-	0x8f, 0x1e, 0x18, 0x72, 0x7e, 0x08, 0x72, 0xa1, 0xdc, 0xd5, 0x76, 0x67, 0x30, 0xf6, 0xf6, 0x09,
-	0x74, 0x16, 0xc1, 0xff, 0xda, 0xd9, 0xdd, 0x11, 0x34, 0x8a, 0x35, 0x82, 0x00, 0x6a, 0x87, 0x22,
-	0x4d, 0x05, 0xef, 0x96, 0xcc, 0xb7, 0xb9, 0xc8, 0x34, 0xeb, 0x06, 0xd6, 0x9f, 0x30, 0xca, 0x75,
-	0xb7, 0xbc, 0xfb, 0x7b, 0x90, 0x4f, 0xc2, 0x46, 0xb4, 0xa0, 0xfe, 0xdd, 0x0b, 0x6b, 0x76, 0x4b,
-	0x08, 0xc3, 0xc6, 0xd1, 0x6b, 0xf7, 0x7f, 0x21, 0x7f, 0x03, 0x0e, 0x09, 0xd0, 0x3a, 0xac, 0x9e,
-	0x68, 0x2d, 0x0f, 0x45, 0x9c, 0xbb, 0xca, 0xa8, 0x03, 0xf0, 0x4c, 0x4c, 0x5f, 0x25, 0xce, 0xae,
-	0x18, 0xa5, 0xd3, 0x57, 0xce, 0x58, 0x41, 0x9b, 0xb0, 0xfe, 0x0b, 0x8f, 0xc8, 0xec, 0xe2, 0xa5,
-	0x3e, 0x7a, 0x1d, 0x51, 0x69, 0x36, 0x67, 0xb7, 0x6a, 0x64, 0x0e, 0x66, 0x8a, 0x71, 0xaa, 0x94,
-	0x63, 0xd6, 0x50, 0x13, 0xaa, 0xcf, 0x09, 0x67, 0x51, 0xb7, 0xbe, 0xfb, 0x08, 0x1a, 0x45, 0x4f,
-	0xd0, 0x1a, 0xb4, 0x5c, 0xf5, 0x45, 0x05, 0x4d, 0xa8, 0xe6, 0x99, 0x0f, 0xf6, 0xdf, 0xdc, 0xf4,
-	0x82, 0xb7, 0x37, 0xbd, 0xe0, 0xef, 0x9b, 0x5e, 0xf0, 0xdb, 0x6d, 0xaf, 0xf4, 0xf6, 0xb6, 0x57,
-	0xfa, 0xf3, 0xb6, 0x57, 0x82, 0x41, 0x24, 0xd2, 0xd1, 0x8b, 0x6b, 0x4d, 0x63, 0xf3, 0xfb, 0x69,
-	0xbf, 0x88, 0x4c, 0x17, 0xa6, 0xf3, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0x13, 0x0b, 0x2f, 0x80,
-	0x13, 0x09, 0x00, 0x00,
+	proto.RegisterFile("internal_trace.proto", fileDescriptor_internal_trace_df2579634d3ea5ed)
+}
+
+var fileDescriptor_internal_trace_df2579634d3ea5ed = []byte{
+	// 1215 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x56, 0x4d, 0x6f, 0x1c, 0x35,
+	0x18, 0xce, 0xec, 0x57, 0x76, 0xde, 0xdd, 0x6c, 0xb6, 0x56, 0x1a, 0xac, 0x00, 0xdb, 0x55, 0x10,
+	0xed, 0x12, 0xd0, 0x16, 0xe5, 0x80, 0x68, 0xd5, 0x02, 0x4d, 0x9a, 0x34, 0x0b, 0x4d, 0xa9, 0x76,
+	0x8b, 0x38, 0x8e, 0xdc, 0x19, 0x27, 0xb5, 0x32, 0xe3, 0x71, 0xc7, 0xde, 0xd0, 0xdc, 0xf8, 0x09,
+	0xdc, 0xf8, 0x27, 0x5c, 0xf8, 0x03, 0x3d, 0xf6, 0x06, 0x17, 0x10, 0x6a, 0x6f, 0xfc, 0x0a, 0x64,
+	0x7b, 0x66, 0xd6, 0x9b, 0x4d, 0xa4, 0xaa, 0x07, 0x6e, 0xe3, 0xf7, 0x79, 0xfc, 0xf8, 0xf5, 0xfb,
+	0xe5, 0x81, 0x35, 0xc6, 0x15, 0xcd, 0x38, 0x89, 0x03, 0x95, 0x91, 0x90, 0x0e, 0x45, 0x96, 0xaa,
+	0x14, 0xb5, 0xcd, 0x22, 0x48, 0xd2, 0x88, 0xc6, 0x72, 0xf3, 0x00, 0xda, 0x4f, 0xf4, 0x7a, 0x37,
+	0x8d, 0x63, 0x1a, 0x2a, 0xb4, 0x0e, 0x0d, 0x83, 0x4b, 0xec, 0xf5, 0xab, 0x83, 0xf6, 0x38, 0x5f,
+	0xa1, 0x3e, 0x34, 0x9f, 0xa5, 0x52, 0x71, 0x92, 0x50, 0x5c, 0xe9, 0x57, 0x06, 0xfe, 0x4e, 0xed,
+	0xe5, 0xdf, 0xd7, 0x96, 0xc6, 0xa5, 0x75, 0xf3, 0xf7, 0x1a, 0xd4, 0x8d, 0x14, 0xba, 0x01, 0x6d,
+	0x49, 0xb3, 0x53, 0x16, 0xd2, 0x40, 0x9d, 0x09, 0x8a, 0x3d, 0x87, 0xdf, 0xca, 0x91, 0x27, 0x67,
+	0x82, 0xa2, 0x1e, 0x2c, 0xe7, 0xcb, 0x39, 0xcd, 0xc2, 0x88, 0xae, 0x41, 0xd3, 0x3a, 0xcb, 0x22,
+	0x5c, 0x75, 0x09, 0xc6, 0x3a, 0x8a, 0xd0, 0x3a, 0x54, 0x29, 0x3f, 0xc5, 0x35, 0x07, 0xd3, 0x06,
+	0x34, 0x80, 0xba, 0x14, 0x84, 0x4b, 0x5c, 0xef, 0x57, 0x07, 0xad, 0x6d, 0x34, 0x74, 0xef, 0x3c,
+	0x9c, 0x08, 0xc2, 0xc7, 0x96, 0xa0, 0x7d, 0x0d, 0x53, 0xae, 0x08, 0xe3, 0x34, 0xd3, 0xc7, 0x34,
+	0xfa, 0xde, 0xcc, 0xd7, 0x12, 0xb1, 0x47, 0x09, 0x16, 0xe1, 0xe5, 0xbe, 0x37, 0xa8, 0x16, 0x47,
+	0x09, 0x16, 0xa1, 0x6d, 0x40, 0x22, 0x4b, 0x43, 0x2a, 0x65, 0x20, 0x15, 0xc9, 0x54, 0xa0, 0x58,
+	0x42, 0x71, 0xd3, 0xa1, 0x75, 0x73, 0x7c, 0xa2, 0xe1, 0x27, 0x2c, 0xa1, 0xe8, 0x63, 0x68, 0x31,
+	0x2e, 0x15, 0xe1, 0xf6, 0x6a, 0xbe, 0x73, 0x26, 0x14, 0xc0, 0x28, 0x42, 0xef, 0x43, 0x83, 0x08,
+	0xa1, 0x19, 0xdf, 0x38, 0x8c, 0x3a, 0x11, 0x62, 0x14, 0xcd, 0x25, 0xe4, 0x9e, 0x03, 0x97, 0x56,
+	0x34, 0x84, 0x2e, 0x11, 0xcc, 0xf8, 0x13, 0x48, 0x1a, 0xa6, 0x3c, 0x92, 0x78, 0xc7, 0xf1, 0xab,
+	0x43, 0x04, 0xd3, 0xee, 0x4c, 0x2c, 0x66, 0xd2, 0x46, 0x12, 0x11, 0xd3, 0xe0, 0x28, 0x26, 0xc7,
+	0x12, 0xef, 0xf6, 0xbd, 0x41, 0xbd, 0x4c, 0x9b, 0x41, 0xf6, 0x35, 0x80, 0x3e, 0x81, 0x95, 0x9c,
+	0xf8, 0x13, 0x65, 0xc7, 0xcf, 0x14, 0xbe, 0xef, 0x30, 0x73, 0x8d, 0x1f, 0x0d, 0xa2, 0x35, 0x15,
+	0x61, 0x71, 0x60, 0x8d, 0x11, 0xde, 0xeb, 0x7b, 0x83, 0x66, 0xa1, 0xa9, 0x91, 0x89, 0x05, 0x36,
+	0x7f, 0xf3, 0xa1, 0xa6, 0xf3, 0x82, 0x3e, 0x84, 0x65, 0x9d, 0x19, 0x7d, 0x6b, 0xb7, 0x6e, 0x1a,
+	0xda, 0x38, 0x8a, 0xd0, 0x16, 0x74, 0x04, 0xc9, 0x28, 0x57, 0x41, 0xc1, 0x72, 0x2b, 0xa7, 0x6d,
+	0xb1, 0x89, 0xe5, 0x7e, 0x0a, 0x9d, 0x54, 0xd0, 0x8c, 0x28, 0x96, 0xf2, 0xc0, 0x04, 0xca, 0x2d,
+	0xa2, 0x95, 0x12, 0x7b, 0xa4, 0xa3, 0x75, 0x1b, 0xd6, 0x67, 0xf9, 0x0b, 0x12, 0x16, 0xc7, 0xcc,
+	0x06, 0xcd, 0x54, 0x57, 0x11, 0xb3, 0x35, 0x59, 0x24, 0xf1, 0x70, 0xc6, 0x40, 0x5f, 0xc0, 0x1a,
+	0xe5, 0xd1, 0xe2, 0xce, 0xba, 0xb3, 0x13, 0x51, 0x1e, 0x9d, 0xdf, 0x77, 0x0b, 0xae, 0x46, 0xd3,
+	0xdc, 0xbf, 0x84, 0x85, 0x59, 0x5a, 0xa4, 0xa9, 0xe1, 0x1e, 0x59, 0x50, 0x0e, 0x1d, 0x06, 0xfa,
+	0x00, 0x1a, 0x52, 0x11, 0x35, 0x95, 0x78, 0xd9, 0xe1, 0xe6, 0x36, 0x74, 0x0b, 0x7c, 0x13, 0x1e,
+	0xd3, 0x7e, 0xcd, 0x7e, 0x65, 0xd0, 0xd9, 0x5e, 0x5f, 0xec, 0x01, 0xdd, 0x83, 0x45, 0xd5, 0xc8,
+	0x7c, 0xbd, 0x10, 0x87, 0xf2, 0x4c, 0xec, 0x5f, 0x12, 0x87, 0x92, 0x81, 0xee, 0x82, 0x2f, 0x48,
+	0x46, 0x92, 0x80, 0x71, 0x85, 0xd7, 0x4c, 0xeb, 0xf5, 0x17, 0x8f, 0x1d, 0x3e, 0xd6, 0x9c, 0x11,
+	0x57, 0x7b, 0x5c, 0x65, 0x67, 0xe3, 0xa6, 0xc8, 0x97, 0x68, 0x17, 0x5a, 0x76, 0xfb, 0x51, 0x9c,
+	0x12, 0x85, 0xaf, 0x1a, 0x81, 0xcd, 0xcb, 0x04, 0xf6, 0x35, 0xc9, 0x4a, 0x80, 0x28, 0x0d, 0x68,
+	0x1f, 0xda, 0x56, 0x44, 0xaa, 0x8c, 0xf1, 0x63, 0xbc, 0x6e, 0x54, 0x3e, 0xba, 0x4c, 0x65, 0x62,
+	0x58, 0x56, 0xa6, 0x25, 0x66, 0x16, 0xdd, 0x5f, 0x19, 0x95, 0xe9, 0x34, 0x0b, 0x29, 0x1e, 0xb8,
+	0xfd, 0x55, 0x58, 0xd1, 0xe7, 0x70, 0x25, 0x24, 0x71, 0x1c, 0xcc, 0xcd, 0xba, 0x3b, 0x0e, 0x75,
+	0x55, 0xc3, 0x13, 0x67, 0xde, 0xe9, 0x61, 0xe3, 0xec, 0xc0, 0x77, 0xe7, 0x86, 0xcd, 0x8c, 0xac,
+	0x3b, 0xcc, 0x10, 0x4b, 0x0f, 0xbe, 0x72, 0x98, 0x46, 0x63, 0x5c, 0x78, 0xf1, 0x35, 0xac, 0xd2,
+	0x2c, 0x4b, 0xb3, 0x80, 0xf1, 0xa3, 0x34, 0x88, 0x99, 0x54, 0x78, 0xdf, 0x5c, 0xf9, 0xbd, 0xf9,
+	0x2b, 0xef, 0x69, 0xd2, 0x88, 0x1f, 0xa5, 0xe3, 0x15, 0x5a, 0x7c, 0x3e, 0x64, 0x52, 0xa1, 0x9b,
+	0xd0, 0xa0, 0xa7, 0x94, 0x2b, 0x89, 0x1f, 0x5c, 0xb4, 0x4f, 0x87, 0x6a, 0x4f, 0xe3, 0xe3, 0x9c,
+	0x86, 0x3e, 0x83, 0x7a, 0xcc, 0xf8, 0x89, 0xc4, 0x07, 0x86, 0x7f, 0x41, 0x61, 0x3d, 0x64, 0xfc,
+	0x64, 0x6c, 0x49, 0x1b, 0x0f, 0x60, 0x65, 0x2e, 0xdf, 0x7a, 0x90, 0x9e, 0xd0, 0x33, 0xec, 0x39,
+	0x37, 0xd2, 0x06, 0xb4, 0x01, 0xf5, 0x53, 0x12, 0x4f, 0xf5, 0x53, 0x30, 0x9b, 0x51, 0xd6, 0x74,
+	0xbb, 0xf2, 0xa5, 0xb7, 0x31, 0x82, 0xd5, 0x73, 0x79, 0x7f, 0x3b, 0x29, 0x6f, 0x51, 0xea, 0x5b,
+	0xe8, 0x9e, 0x4f, 0xfe, 0xdb, 0x69, 0xf9, 0x0b, 0x5a, 0x9b, 0x7f, 0x54, 0xc0, 0x2f, 0x63, 0x8b,
+	0xee, 0x00, 0xd8, 0x6c, 0x9c, 0x30, 0x1e, 0x19, 0xb1, 0xce, 0x85, 0x89, 0x70, 0x5a, 0xcf, 0x37,
+	0x1b, 0xbe, 0x63, 0x3c, 0xd2, 0x69, 0xb7, 0xbb, 0x13, 0x2a, 0x25, 0x39, 0x9e, 0x3f, 0xb3, 0x6d,
+	0xa0, 0x43, 0x8b, 0xa0, 0x6b, 0xd0, 0xb2, 0x54, 0xa9, 0x48, 0x78, 0x82, 0xab, 0xfd, 0xea, 0xc0,
+	0x1f, 0xdb, 0xb3, 0x27, 0xda, 0xa2, 0xa7, 0xbf, 0x25, 0xa4, 0x61, 0x38, 0xcd, 0xec, 0xab, 0x54,
+	0x73, 0xa7, 0xbf, 0x41, 0xbf, 0xd7, 0xa0, 0x79, 0x93, 0xf6, 0x0b, 0xcf, 0x95, 0x9e, 0xfd, 0x3f,
+	0x57, 0x4c, 0x6e, 0xaf, 0x5f, 0x52, 0x43, 0xf9, 0x25, 0xc8, 0xb1, 0xb4, 0x9d, 0xe3, 0xd3, 0x62,
+	0xbd, 0x71, 0x00, 0x9d, 0x79, 0xf0, 0x9d, 0x23, 0xfb, 0xaf, 0x07, 0x7e, 0x59, 0x7d, 0x08, 0x43,
+	0xcd, 0x8c, 0x70, 0x57, 0xc6, 0x58, 0xd0, 0x4d, 0xe8, 0x2e, 0xcc, 0x2a, 0xb7, 0x86, 0x56, 0xd5,
+	0xb9, 0x31, 0xf5, 0x00, 0x80, 0x28, 0x95, 0xb1, 0xa7, 0x53, 0x45, 0xa5, 0x09, 0x5d, 0x6b, 0xfb,
+	0xc6, 0x25, 0x55, 0x3f, 0xbc, 0x57, 0x32, 0xf3, 0x59, 0x33, 0xdb, 0xaa, 0x4b, 0xf2, 0x1c, 0xfc,
+	0xce, 0x97, 0xfd, 0xcb, 0x83, 0x66, 0xd1, 0x3a, 0x73, 0xff, 0x3d, 0xae, 0x52, 0xf9, 0xdf, 0xe3,
+	0x3c, 0x92, 0xae, 0x5e, 0xf1, 0x48, 0xee, 0x5f, 0x70, 0xc1, 0xeb, 0x17, 0xb7, 0xe9, 0xff, 0x74,
+	0xbf, 0xad, 0xa1, 0xbd, 0x9e, 0x19, 0x83, 0x00, 0x8d, 0xdd, 0x34, 0x49, 0x52, 0xde, 0x5d, 0xd2,
+	0xdf, 0x7a, 0xe8, 0xd1, 0xac, 0xeb, 0x19, 0x7b, 0xcc, 0x28, 0x57, 0xdd, 0xca, 0xd6, 0xaf, 0x5e,
+	0xde, 0x56, 0x66, 0x47, 0x0b, 0x96, 0xef, 0x3f, 0x35, 0xcb, 0xee, 0x12, 0xc2, 0xb0, 0xb6, 0xf7,
+	0xc2, 0xfe, 0xd8, 0xe6, 0xf3, 0xd2, 0x22, 0x1e, 0xba, 0x02, 0x2b, 0x07, 0x4a, 0x89, 0xdd, 0x34,
+	0xca, 0x4d, 0x15, 0xd4, 0x01, 0x78, 0x94, 0x4e, 0x9e, 0xc7, 0x76, 0x5d, 0xd5, 0x4a, 0x87, 0xcf,
+	0xed, 0xa2, 0x86, 0xae, 0xc2, 0x95, 0x1f, 0x78, 0x48, 0xa6, 0xc7, 0xcf, 0xd4, 0xde, 0x8b, 0x90,
+	0x0a, 0xfd, 0xca, 0x76, 0xeb, 0x5a, 0x66, 0x67, 0x2a, 0x19, 0xa7, 0x52, 0x5a, 0x66, 0x03, 0xf9,
+	0x50, 0x7f, 0x4c, 0x38, 0x0b, 0xbb, 0xcb, 0x5b, 0xd7, 0xa1, 0x59, 0x14, 0x38, 0x5a, 0x85, 0x96,
+	0xf5, 0xbe, 0xf0, 0xc0, 0x87, 0x7a, 0x7e, 0xf2, 0xce, 0x9d, 0x97, 0xaf, 0x7b, 0xde, 0xab, 0xd7,
+	0x3d, 0xef, 0x9f, 0xd7, 0x3d, 0xef, 0x97, 0x37, 0xbd, 0xa5, 0x57, 0x6f, 0x7a, 0x4b, 0x7f, 0xbe,
+	0xe9, 0x2d, 0xc1, 0x66, 0x98, 0x26, 0xc3, 0xa7, 0x67, 0x8a, 0x46, 0xfa, 0x47, 0xcf, 0x7c, 0x11,
+	0x91, 0xcc, 0xe5, 0xe7, 0xbf, 0x01, 0x00, 0xf0, 0x96, 0xd5, 0xbd, 0xbc, 0x0b, 0x00, 0x00,
 }
@@ -2,9 +2,14 @@ package aitracer
 
 import (
 	"context"
+	"encoding/binary"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/volcengine/apminsight-server-sdk-go/internal/flush"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
 	"github.com/volcengine/apminsight-server-sdk-go/metrics"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/id_generator"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/log_collector"
@@ -15,6 +20,7 @@ import (
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sampler"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/sendworker"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/service_register"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/service_register/register_utils"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/settings_fetcher"
@@ -28,7 +34,8 @@ var (
 type tracer struct {
 	logger logger.Logger
 
-	metricsClient *metrics.MetricsClient
+	metricsClient  *metrics.MetricsClient
+	metricsClients []*metrics.MetricsClient // all clients, including those of runtime and self stats metrics
 
 	serviceType string
 	service     string
@@ -44,14 +51,24 @@ type tracer struct {
 
 	traceSenders []*trace_sender.TraceSender
 
+	traceSpill    *sendworker.SpillBuffer
+	traceOverflow *sendworker.SpillBuffer
+	spillChan     chan *trace_models.Trace // traces dropped by full traceChan, written to traceOverflow by spill goroutine
+	spillDone     chan struct{}            // spillChan is never closed, since spans may still finish after stopped
+	spillWg       sync.WaitGroup
+
 	serviceRegister *service_register.Register
 
-	traceSampler *trace_sampler.Sampler
+	traceSampler      *trace_sampler.Sampler
+	localSamplerRules []trace_sampler.SamplerRule
+	tailSampler       *tailSampler
 
 	settingsFetcher *settings_fetcher.Fetcher
 
 	runtimeMonitor *runtime.Monitor
 
+	selfStatsReporter *selfStatsReporter
+
 	containerId string
 	instanceId  string
 
@@ -60,6 +77,9 @@ type tracer struct {
 	contextAdapter func(context.Context) context.Context
 
 	metricTagKeysRegister tags.MetricTagKeysRegister
+	spanMetrics           spanMetrics
+
+	pprofLabels bool // set pprof labels of server spans to goroutines
 }
 
 func NewTracer(serviceType, service string, opts ...TracerOption) Tracer {
@@ -75,7 +95,9 @@ func NewTracer(serviceType, service string, opts ...TracerOption) Tracer {
 
 		idGenerator: id_generator.New(),
 
-		traceSampler: trace_sampler.New(),
+		traceSampler:      trace_sampler.New(),
+		localSamplerRules: config.SamplerRules,
+		tailSampler:       newTailSampler(config.TailSampling),
 
 		traceChan: make(chan *trace_models.Trace, config.SenderChanSize),
 
@@ -87,6 +109,12 @@ func NewTracer(serviceType, service string, opts ...TracerOption) Tracer {
 	{
 		t.instanceId = register_utils.GetInstanceID()
 	}
+	if len(t.localSamplerRules) > 0 {
+		t.traceSampler.RefreshConfig(trace_sampler.SamplerConfig{
+			Strategy: trace_sampler.SamplerStrategyAll,
+			Rules:    t.localSamplerRules,
+		})
+	}
 	info, _ := register_utils.GetInfo()
 	if len(info.ContainerId) != 0 {
 		t.containerId = info.ContainerId
@@ -98,46 +126,118 @@ func NewTracer(serviceType, service string, opts ...TracerOption) Tracer {
 			WorkerNumber: config.LogSenderNumber,
 			ChanSize:     config.LogSenderChanSize,
 			Debug:        config.LogSenderDebug,
+			Schema:       config.HTTPSchema,
+			Host:         config.HTTPHost,
+			Timeout:      config.HTTPTimeout,
+			OTLP:         config.OTLP.toOTLPHTTPConfig(),
 		}
 		t.logCollector = log_collector.NewLogCollector(config)
 	}
+	var metricOpts []metrics.ClientOption
+	if config.MetricSock != "" {
+		metricOpts = append(metricOpts, metrics.WithAddress(config.MetricSock))
+	}
+	if config.OTLP.Endpoint != "" {
+		metricOpts = append(metricOpts, metrics.WithOTLP(config.OTLP.toMetricsOTLPConfig(serviceType, service, t.instanceId)))
+	}
+	if config.EnablePrometheus {
+		metricOpts = append(metricOpts, metrics.WithPrometheus(true))
+	}
+	if config.DisableMetricSender {
+		metricOpts = append(metricOpts, metrics.WithSender(false))
+	}
 	if config.EnableRuntimeMetric {
-		var mc *metrics.MetricsClient
-		if config.MetricSock != "" {
-			mc = metrics.NewMetricClient(metrics.WithAddress(config.MetricSock))
-		} else {
-			mc = metrics.NewMetricClient()
-		}
+		mc := metrics.NewMetricClient(metricOpts...)
 		t.runtimeMonitor = runtime.NewMonitor(serviceType, service, mc)
+		t.metricsClients = append(t.metricsClients, mc)
 	}
 	if config.EnableMetric {
-		if config.MetricSock != "" {
-			t.metricsClient = metrics.NewMetricClient(metrics.WithAddress(config.MetricSock))
-		} else {
-			t.metricsClient = metrics.NewMetricClient()
+		spanMetricOpts := append([]metrics.ClientOption{}, metricOpts...)
+		if config.MetricAggregation {
+			spanMetricOpts = append(spanMetricOpts, metrics.WithAggregation(true))
 		}
-	}
-
-	t.serviceRegister = service_register.GetRegister(serviceType, service, service_register.Config{Sock: config.ServerRegisterSock, Interval: time.Second * 30, Logger: t.logger})
+		t.metricsClient = metrics.NewMetricClient(spanMetricOpts...)
+		t.metricsClients = append(t.metricsClients, t.metricsClient)
+	}
+	if config.EnableSelfStatsMetric {
+		mc := metrics.NewMetricClient(metricOpts...)
+		t.selfStatsReporter = newSelfStatsReporter(serviceType, service, t.instanceId, mc)
+		t.metricsClients = append(t.metricsClients, mc)
+	}
+
+	t.serviceRegister = service_register.GetRegister(serviceType, service, service_register.Config{
+		Sock:     config.ServerRegisterSock,
+		Schema:   config.HTTPSchema,
+		Host:     config.HTTPHost,
+		Timeout:  config.HTTPTimeout,
+		Interval: time.Second * 30,
+		Logger:   t.logger,
+	})
 	for _, p := range config.PropagatorConfigs {
 		t.injects[p.Format] = p.Injector
 		t.extractors[p.Format] = p.Extractor
 	}
+	// Composite configured by WithPropagator is kept
+	composite := newCompositePropagator(config.CompositeFormats, t.injects, t.extractors)
+	if _, ok := t.injects[Composite]; !ok {
+		t.injects[Composite] = composite
+	}
+	if _, ok := t.extractors[Composite]; !ok {
+		t.extractors[Composite] = composite
+	}
+	if config.SpillDir != "" && config.OTLP.Endpoint == "" && config.HTTPHost == "" {
+		t.initSpillBuffer(config)
+	}
 	for i := 0; i < config.SenderNumber; i++ {
-		t.traceSenders = append(t.traceSenders, trace_sender.NewTraceSender(config.SenderSock, config.SenderStreamSock, t.traceChan, t.logger))
+		if config.OTLP.Endpoint != "" {
+			t.traceSenders = append(t.traceSenders, trace_sender.NewOTLPTraceSender(config.OTLP.toOTLPHTTPConfig(), t.traceChan, t.logger))
+		} else if config.HTTPHost != "" {
+			t.traceSenders = append(t.traceSenders, trace_sender.NewHTTPTraceSender(config.HTTPSchema, config.HTTPHost, config.HTTPTimeout, t.traceChan, t.logger))
+		} else {
+			sender := trace_sender.NewTraceSender(config.SenderSock, config.SenderStreamSock, t.traceChan, t.logger)
+			sender.SetSpillBuffer(t.traceSpill, t.traceOverflow)
+			t.traceSenders = append(t.traceSenders, sender)
+		}
 	}
 	t.settingsFetcher = settings_fetcher.NewSettingsFetcher(settings_fetcher.SettingsFetcherConfig{
 		Service: t.service,
 		Logger:  t.logger,
 		Sock:    config.SettingsFetcherSock,
+		Schema:  config.HTTPSchema,
+		Host:    config.HTTPHost,
+		Timeout: config.HTTPTimeout,
 		Notifier: []func(*settings_models.Settings){
 			t.handleSettings,
 		},
 	})
 	t.contextAdapter = config.ContextAdapter
+	t.pprofLabels = config.EnablePprofLabels
 	return t
 }
 
+// initSpillBuffer creates spill buffers shared by trace senders. Spill is disabled if it fails
+func (t *tracer) initSpillBuffer(config TracerConfig) {
+	var err error
+	if t.traceSpill, err = sendworker.NewSpillBuffer(sendworker.SpillConfig{
+		Dir:      filepath.Join(config.SpillDir, "trace_batch"),
+		MaxBytes: config.SpillMaxBytes / 2,
+		MaxAge:   config.SpillMaxAge,
+	}); err != nil {
+		t.logger.Error("create trace spill buffer err %v", err)
+	}
+	if t.traceOverflow, err = sendworker.NewSpillBuffer(sendworker.SpillConfig{
+		Dir:      filepath.Join(config.SpillDir, "trace_overflow"),
+		MaxBytes: config.SpillMaxBytes / 2,
+		MaxAge:   config.SpillMaxAge,
+	}); err != nil {
+		t.logger.Error("create trace overflow buffer err %v", err)
+	}
+	if t.traceOverflow != nil {
+		t.spillChan = make(chan *trace_models.Trace, config.SenderChanSize)
+		t.spillDone = make(chan struct{})
+	}
+}
+
 func (t *tracer) Start() {
 	t.settingsFetcher.Start()
 	if t.logCollector != nil {
@@ -150,24 +250,130 @@ func (t *tracer) Start() {
 	for _, sender := range t.traceSenders {
 		sender.Start()
 	}
+	if t.spillChan != nil {
+		t.spillWg.Add(1)
+		go t.runSpill()
+	}
 	t.serviceRegister.Start()
-	t.runtimeMonitor.Start()
+	if t.runtimeMonitor != nil {
+		t.runtimeMonitor.Start()
+	}
+	if t.selfStatsReporter != nil {
+		t.selfStatsReporter.Start()
+	}
 }
 
 func (t *tracer) Stop() {
+	_ = t.StopWithContext(context.Background())
+}
+
+// StopWithContext stops tracer like Stop, but gives up waiting for traces, logs and metrics to be sent when ctx is done.
+// *StopError is returned with the numbers of data dropped if ctx is done before all are sent.
+// If ctx is done while waiting for traces, metrics and logs are given stopGracePeriod each to be sent
+func (t *tracer) StopWithContext(ctx context.Context) error {
 	t.serviceRegister.Stop()
-	t.runtimeMonitor.Close()
+	if t.runtimeMonitor != nil {
+		t.runtimeMonitor.Stop()
+	}
+	var stopErr *StopError
+	lost := func(err error) *StopError {
+		if stopErr == nil {
+			stopErr = &StopError{Err: err}
+		}
+		return stopErr
+	}
 	close(t.traceChan)
-	for _, sender := range t.traceSenders {
-		sender.WaitStop()
+	if t.spillChan != nil {
+		close(t.spillDone)
 	}
-	if t.metricsClient != nil {
-		t.metricsClient.Close()
+	if err := flush.Wait(ctx, func() {
+		t.spillWg.Wait()
+		for _, sender := range t.traceSenders {
+			sender.WaitStop()
+		}
+	}); err != nil {
+		n := 0
+		for range t.traceChan { // closed, receive until empty
+			n++
+		}
+		if t.spillChan != nil {
+			n += t.drainSpill(func(*trace_models.Trace) {})
+		}
+		selfstats.Trace.AddDropped(int64(n))
+		lost(err).Traces = n
+	}
+	// spill buffers are used by senders, close them after senders stopped or given up.
+	// senders still running get ErrSpillBufferClosed and drop traces
+	if t.traceSpill != nil {
+		t.traceSpill.Close()
+	}
+	if t.traceOverflow != nil {
+		t.traceOverflow.Close()
+	}
+	// self stats are reported for the last time before clients closed
+	if t.selfStatsReporter != nil {
+		t.selfStatsReporter.Stop()
+	}
+	for _, mc := range t.metricsClients {
+		graceCtx, cancel := withGracePeriod(ctx)
+		if n, err := mc.CloseWithContext(graceCtx); err != nil {
+			lost(err).Metrics += n
+		}
+		cancel()
 	}
 	if t.logCollector != nil {
-		t.logCollector.Stop()
+		graceCtx, cancel := withGracePeriod(ctx)
+		if n, err := t.logCollector.StopWithContext(graceCtx); err != nil {
+			lost(err).Logs = n
+		}
+		cancel()
 	}
 	t.settingsFetcher.Stop()
+	if stopErr != nil {
+		t.logger.Error("%v", stopErr)
+		return stopErr
+	}
+	return nil
+}
+
+// withGracePeriod returns ctx if it is not done, otherwise a new context of stopGracePeriod,
+// so that data sent quickly is not dropped only because waiting for others has used up ctx
+func withGracePeriod(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx.Err() == nil {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(context.Background(), stopGracePeriod)
+}
+
+// Flush sends traces, logs and metrics queued and buffered, and waits for them to be sent until ctx is done.
+// Traces of spans not finished are not included
+func (t *tracer) Flush(ctx context.Context) error {
+	var firstErr error
+	for _, sender := range t.traceSenders {
+		if err := sender.Flush(ctx); err != nil {
+			t.logger.Error("flush traces err %v", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if t.logCollector != nil {
+		if err := t.logCollector.Flush(ctx); err != nil {
+			t.logger.Error("flush logs err %v", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	for _, mc := range t.metricsClients {
+		if err := mc.Flush(ctx); err != nil {
+			t.logger.Error("flush metrics err %v", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
 }
 
 func (t *tracer) Extract(format interface{}, carrier interface{}) (SpanContext, error) {
@@ -217,7 +423,11 @@ func (t *tracer) StartServerSpan(operationName string, opts ...StartSpanOption)
 }
 
 func (t *tracer) StartServerSpanFromContext(ctx context.Context, operationName string, opts ...StartSpanOption) (Span, context.Context) {
-	return t.startSpanFromContext(ctx, operationName, StartSpanConfig{spanType: serverSpanType}, opts...)
+	sp, spanCtx := t.startSpanFromContext(ctx, operationName, StartSpanConfig{spanType: serverSpanType}, opts...)
+	if s, ok := sp.(*span); ok && t.pprofLabels && ctx != nil {
+		spanCtx = s.setPprofLabels(ctx, spanCtx)
+	}
+	return sp, spanCtx
 }
 
 func (t *tracer) StartClientSpan(operationName string, opts ...StartSpanOption) Span {
@@ -237,7 +447,10 @@ func (t *tracer) startSpan(operationName string, defaultConfig StartSpanConfig,
 		opt(&defaultConfig)
 	}
 
-	startTime := time.Now()
+	startTime := defaultConfig.StartTime
+	if startTime.IsZero() {
+		startTime = time.Now()
+	}
 	spanId := t.idGenerator.GenId()
 	parentSpanID := ""
 	var (
@@ -267,6 +480,9 @@ func (t *tracer) startSpan(operationName string, defaultConfig StartSpanConfig,
 				tracer:        t,
 			}
 			traceCtx.sampleStrategy, traceCtx.sampleWeight = defaultConfig.parentSpanContext.Sample()
+			if tsc, ok := defaultConfig.parentSpanContext.(w3cTraceStateCarrier); ok {
+				traceCtx.traceState = tsc.w3cTraceState()
+			}
 		}
 
 		// copy parent baggage to new span
@@ -286,7 +502,11 @@ func (t *tracer) startSpan(operationName string, defaultConfig StartSpanConfig,
 		}
 	}
 	if traceCtx.sampleStrategy == SampleStrategyUnknown {
-		sampled, weight := t.traceSampler.Sample()
+		sampled, weight := t.traceSampler.SampleWith(trace_sampler.SampleAttributes{
+			Resource:  traceCtx.resource,
+			Operation: operationName,
+			Baggage:   incomingBaggage,
+		})
 		if sampled {
 			traceCtx.sampleStrategy = SampleStrategySampled
 			traceCtx.sampleWeight = weight
@@ -331,6 +551,9 @@ func (t *tracer) startSpan(operationName string, defaultConfig StartSpanConfig,
 			baggage:      incomingBaggage,
 		},
 	}
+	for _, link := range defaultConfig.Links {
+		s.AddLink(link.SpanContext, link.Attributes)
+	}
 	s.spanContext.traceContext.addSpan(s)
 	return s
 }
@@ -378,22 +601,59 @@ func (t *tracer) handleSettingsForSampler(settings *settings_models.Settings) {
 		return
 	}
 	samplerConfig := trace_sampler.SamplerConfig{}
-	switch settings.Trace.SampleConfig.Strategy {
+	strategy, ok := toSamplerStrategy(settings.Trace.SampleConfig.Strategy)
+	if !ok {
+		return
+	}
+	if err := trace_sampler.ValidateSamplerValue(strategy, settings.Trace.SampleConfig.Value); err != nil {
+		t.logger.Error("%v of sample config %+v", err, settings.Trace.SampleConfig)
+		return
+	}
+	samplerConfig.Strategy = strategy
+	samplerConfig.Value = settings.Trace.SampleConfig.Value
+
+	// remote rules first, then local rules
+	for _, r := range settings.Trace.SampleConfig.Rules {
+		if r == nil {
+			continue
+		}
+		ruleStrategy, ok := toSamplerStrategy(r.Strategy)
+		if !ok {
+			continue
+		}
+		if err := trace_sampler.ValidateSamplerValue(ruleStrategy, r.Value); err != nil {
+			t.logger.Error("%v of sampler rule %+v", err, r)
+			continue
+		}
+		samplerConfig.Rules = append(samplerConfig.Rules, trace_sampler.SamplerRule{
+			Resource:  r.Resource,
+			Operation: r.Operation,
+			Baggage:   r.Baggage,
+			Strategy:  ruleStrategy,
+			Value:     r.Value,
+		})
+	}
+	samplerConfig.Rules = append(samplerConfig.Rules, t.localSamplerRules...)
+	t.traceSampler.RefreshConfig(samplerConfig)
+}
+
+func toSamplerStrategy(strategy settings_models.SampleStrategy) (int, bool) {
+	switch strategy {
 	case settings_models.SampleStrategy_ALL:
-		samplerConfig.Strategy = trace_sampler.SamplerStrategyAll
+		return trace_sampler.SamplerStrategyAll, true
 	case settings_models.SampleStrategy_SAMPLE_RATIO:
-		samplerConfig.Strategy = trace_sampler.SamplerStrategyRatio
+		return trace_sampler.SamplerStrategyRatio, true
 	case settings_models.SampleStrategy_RATE_LIMIT:
-		samplerConfig.Strategy = trace_sampler.SamplerStrategyRateLimit
+		return trace_sampler.SamplerStrategyRateLimit, true
 	default:
-		return
+		return 0, false
 	}
-	samplerConfig.Value = settings.Trace.SampleConfig.Value
-	t.traceSampler.RefreshConfig(samplerConfig)
 }
 
 type tracerDynamicConfig struct {
-	DbSlowQuery time.Duration
+	DbSlowQuery       time.Duration
+	CustomInstruments map[string]*customInstrument // key is ClassName.MethodName
+	ErrorPolicy       *ErrorPolicy
 }
 
 func (t *tracer) getDynamicConfig() *tracerDynamicConfig {
@@ -409,6 +669,16 @@ func (t *tracer) handleSettingsForDynamicConfig(settings *settings_models.Settin
 	if settings.Db != nil {
 		newDynamicConfig.DbSlowQuery = time.Duration(settings.Db.SlowQueryMillseconds) * time.Millisecond
 	}
+	if settings.Trace != nil {
+		newDynamicConfig.CustomInstruments = newCustomInstruments(settings.Trace.CustomInstrumentConfig)
+	}
+	if settings.BusinessError != nil {
+		policy, err := newErrorPolicy(settings.BusinessError)
+		if err != nil {
+			t.logger.Error("invalid crash clazz pattern of business error settings: %v", err)
+		}
+		newDynamicConfig.ErrorPolicy = policy
+	}
 	t.dynamicConfig.Store(newDynamicConfig)
 }
 
@@ -416,17 +686,32 @@ func (t *tracer) handleSettingsForDynamicConfig(settings *settings_models.Settin
 func (t *tracer) collect(tc *traceContext) {
 	// 发送详情
 	if tc.sampleStrategy == SampleStrategySampled || tc.sampleFlags.Sampled() {
-		t.emitTrace(tc)
+		t.emitTrace(tc, false)
+		return
+	}
+	// tail sampling. keep error or slow traces which are not sampled
+	if t.tailSampler != nil && t.tailSampler.shouldKeep(tc) {
+		t.emitTrace(tc, true)
 	}
 }
 
-func (t *tracer) emitTrace(tc *traceContext) {
+func (t *tracer) emitTrace(tc *traceContext, tailSampled bool) {
+	sampleFlags, sampleWeight := tc.sampleFlags, tc.sampleWeight
+	if tailSampled { // kept regardless of sample rate, so it represents only itself
+		sampleFlags, sampleWeight = SampleFlagsServerSampled, 1
+		if tc.clientSampled {
+			sampleFlags = SampleFlagsClientAndServerSampled
+		}
+	}
 	trace := trace_models.Trace{
-		ServiceType: t.serviceType,
-		Service:     t.service,
-		ContainerId: t.containerId,
-		InstanceId:  t.instanceId,
-		TraceId:     tc.traceID,
+		ServiceType:  t.serviceType,
+		Service:      t.service,
+		ContainerId:  t.containerId,
+		InstanceId:   t.instanceId,
+		TraceId:      tc.traceID,
+		SampleFlags:  int32(sampleFlags),
+		SampleWeight: int32(sampleWeight),
+		TailSampled:  tailSampled,
 	}
 	if len(tc.spans) == 0 {
 		return
@@ -493,6 +778,25 @@ func (t *tracer) emitTrace(tc *traceContext) {
 			})
 		}
 
+		span.eventsLock.Lock()
+		var events []*trace_models.SpanEvent
+		for _, event := range span.events {
+			events = append(events, &trace_models.SpanEvent{
+				Name:            event.name,
+				TimeMicrosecond: event.timestamp.Unix()*1e6 + int64(event.timestamp.Nanosecond())/1e3,
+				Attributes:      event.attributes,
+			})
+		}
+		var links []*trace_models.SpanLink
+		for _, link := range span.links {
+			links = append(links, &trace_models.SpanLink{
+				TraceId:    link.SpanContext.TraceID(),
+				SpanId:     link.SpanContext.SpanID(),
+				Attributes: link.Attributes,
+			})
+		}
+		span.eventsLock.Unlock()
+
 		// set appId/origin in tags
 		tagsStr := span.tagsString
 		span.spanContext.ForeachBaggageItem(func(key, value string) bool {
@@ -530,10 +834,77 @@ func (t *tracer) emitTrace(tc *traceContext) {
 			CallService:     span.clientService,
 			CallResource:    span.clientResource,
 			ErrorInfoList:   errorInfoList,
+
+			Events: events,
+			Links:  links,
 		})
 	}
 	select {
 	case t.traceChan <- &trace: //non-blocking. otherwise span.Finish could be blocked.
+		selfstats.Trace.AddEnqueued(1)
 	default:
+		t.dropTrace(&trace)
+	}
+}
+
+// dropTrace hands trace dropped by full traceChan to spill goroutine, so that span.Finish is not blocked by disk.
+// trace is dropped if spill is disabled or spill goroutine is busy too
+func (t *tracer) dropTrace(trace *trace_models.Trace) {
+	if t.spillChan != nil {
+		select {
+		case t.spillChan <- trace:
+			return
+		default:
+		}
+	}
+	selfstats.Trace.AddDropped(1)
+}
+
+// runSpill spills traces until stopped, and then spills those queued
+func (t *tracer) runSpill() {
+	defer t.spillWg.Done()
+	spill := func(trace *trace_models.Trace) {
+		if !t.spillTrace(trace) {
+			selfstats.Trace.AddDropped(1)
+		}
+	}
+	for {
+		select {
+		case trace := <-t.spillChan:
+			spill(trace)
+		case <-t.spillDone:
+			t.drainSpill(spill)
+			return
+		}
+	}
+}
+
+// drainSpill receives traces queued in spillChan without blocking, and returns the number of them
+func (t *tracer) drainSpill(handle func(trace *trace_models.Trace)) int {
+	n := 0
+	for {
+		select {
+		case trace := <-t.spillChan:
+			handle(trace)
+			n++
+		default:
+			return n
+		}
+	}
+}
+
+// spillTrace writes trace dropped by full channel to disk, which is sent by senders later
+func (t *tracer) spillTrace(trace *trace_models.Trace) bool {
+	size := trace.Size()
+	sizePrefixData := make([]byte, 4+size)
+	binary.LittleEndian.PutUint32(sizePrefixData[0:4], uint32(size))
+	if _, err := trace.MarshalTo(sizePrefixData[4:]); err != nil {
+		return false
+	}
+	if err := t.traceOverflow.Push(sizePrefixData); err != nil {
+		t.logger.Error("spill trace err %v", err)
+		return false
 	}
+	selfstats.Trace.AddEnqueued(1)
+	return true
 }
@@ -0,0 +1,96 @@
+package aitracer
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/metrics"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/sendworker"
+)
+
+func TestFlushAndStopWithContext(t *testing.T) {
+	var requests int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&requests, 1)
+	}))
+	defer server.Close()
+
+	tr := NewTracer(Http, "svc", WithSelfStatsMetric(true)).(*tracer)
+	tr.logCollector = nil
+	for _, mc := range tr.metricsClients { // span, runtime and self stats metrics
+		mc.Start()
+	}
+	sender := trace_sender.NewHTTPTraceSender("http", strings.TrimPrefix(server.URL, "http://"), time.Second, tr.traceChan, nil)
+	tr.traceSenders = []*trace_sender.TraceSender{sender}
+	sender.Start()
+
+	tr.StartServerSpan("flushed").Finish()
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	assert.Nil(t, tr.Flush(ctx)) // sent before flush interval
+	assert.Equal(t, int64(1), atomic.LoadInt64(&requests))
+
+	tr.StartServerSpan("stopped").Finish()
+	assert.Nil(t, tr.StopWithContext(ctx))
+	assert.Equal(t, int64(2), atomic.LoadInt64(&requests))
+	assert.Equal(t, 3, len(tr.metricsClients))
+	for _, mc := range tr.metricsClients {
+		assert.Equal(t, metrics.ErrorClientClosed, mc.Flush(ctx))
+	}
+}
+
+func TestStopWithContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	dir, err := ioutil.TempDir("", "spill")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	tr := NewTracer(Http, "svc", WithSpillBuffer(dir, 1<<20, time.Hour)).(*tracer)
+	tr.logCollector, tr.metricsClient, tr.metricsClients = nil, nil, nil
+	sender := trace_sender.NewHTTPTraceSender("http", strings.TrimPrefix(server.URL, "http://"), 5*time.Second, tr.traceChan, nil)
+	tr.traceSenders = []*trace_sender.TraceSender{sender}
+	sender.Start()
+
+	tr.StartServerSpan("blocked").Finish()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var stopErr *StopError
+	assert.ErrorAs(t, tr.StopWithContext(ctx), &stopErr)
+	// spill buffers are closed even if senders are not stopped
+	assert.Equal(t, sendworker.ErrSpillBufferClosed, tr.traceSpill.Push([]byte("a")))
+	assert.Equal(t, sendworker.ErrSpillBufferClosed, tr.traceOverflow.Push([]byte("a")))
+
+	graceCtx, graceCancel := withGracePeriod(ctx)
+	defer graceCancel()
+	assert.Nil(t, graceCtx.Err())
+}
+
+func TestStopError(t *testing.T) {
+	err := &StopError{Err: context.DeadlineExceeded, Traces: 1, Logs: 2, Metrics: 3}
+	assert.Equal(t, "aitracer: stop context deadline exceeded, 1 traces, 2 logs and 3 metrics are dropped", err.Error())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPrometheusHandler(t *testing.T) {
+	tr := NewTracer(Http, "svc", WithMetrics(true), WithPrometheus(true), WithMetricSender(false)).(*tracer)
+	tr.StartServerSpan("server", ServerResourceAs("/a")).Finish()
+
+	rec := httptest.NewRecorder()
+	PrometheusHandler(tr).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Contains(t, rec.Body.String(), "# TYPE apminsight_service_trace_called_throughput counter\n")
+	assert.Contains(t, rec.Body.String(), `resource="/a"`)
+}
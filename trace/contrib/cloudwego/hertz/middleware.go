@@ -19,7 +19,7 @@ func NewMiddleware(tracer aitracer.Tracer) app.HandlerFunc {
 			resourceName = "unknown"
 		}
 
-		chainSpanContext, _ := tracer.Extract(aitracer.HTTPHeaders, aitracer.HTTPHeadersCarrier(hertzHeaderToHttpHeader(&reqCtx.Request.Header)))
+		chainSpanContext, _ := tracer.Extract(aitracer.Composite, aitracer.HTTPHeadersCarrier(hertzHeaderToHttpHeader(&reqCtx.Request.Header)))
 		span := tracer.StartServerSpan("request", aitracer.ChildOf(chainSpanContext), aitracer.ServerResourceAs(resourceName))
 
 		// set span to Context
@@ -47,8 +47,9 @@ func NewMiddleware(tracer aitracer.Tracer) app.HandlerFunc {
 				status = http.StatusInternalServerError
 			}
 			span.SetTag(aitracer.HttpStatusCode, status)
-			if status != http.StatusOK {
-				span.SetStatus(int64(status))
+			if tracer.ErrorPolicy().IsHttpError(status) {
+				span.SetStatus(aitracer.StatusCodeError)
+				span.RecordError(aitracer.HttpCodeError(status), aitracer.WithErrorKind(aitracer.ErrorKindHttpCodeError))
 			}
 		}()
 
@@ -3,6 +3,8 @@ package kitex
 import (
 	"bytes"
 	"context"
+	"errors"
+	"strconv"
 
 	"github.com/bytedance/gopkg/cloud/metainfo"
 	"github.com/cloudwego/kitex/client"
@@ -59,7 +61,7 @@ func NewServerMiddleware(tracer aitracer.Tracer) endpoint.Middleware {
 
 			ctxWithSpan := aitracer.ContextWithSpan(ctx, span)
 			err = next(ctxWithSpan, req, resp)
-			if err != nil {
+			if err != nil && tracer.ErrorPolicy().IsRpcError(errorCodes(err)...) {
 				span.SetStatus(1)
 				span.RecordError(err)
 			}
@@ -118,7 +120,7 @@ func NewClientMiddleware(tracer aitracer.Tracer) endpoint.Middleware {
 			ctxWithSpan = metainfo.WithValue(ctxWithSpan, SpanContextKey, buf.String())
 
 			err = next(ctxWithSpan, req, resp) // pass ctxWithSpan down in case client span has local child
-			if err != nil {
+			if err != nil && tracer.ErrorPolicy().IsRpcError(errorCodes(err)...) {
 				span.SetStatus(1)
 				span.RecordError(err)
 			}
@@ -126,3 +128,18 @@ func NewClientMiddleware(tracer aitracer.Tracer) endpoint.Middleware {
 		}
 	}
 }
+
+// errorCodes returns codes of err matched with RpcErrorCodes of ErrorPolicy, which are type id of remote.TransError
+// like "6", and message of basic error of kerrors.DetailedError like "remote or network error"
+func errorCodes(err error) []string {
+	var codes []string
+	var te interface{ TypeID() int32 }
+	if errors.As(err, &te) {
+		codes = append(codes, strconv.Itoa(int(te.TypeID())))
+	}
+	var de *kerrors.DetailedError
+	if errors.As(err, &de) && de.ErrorType() != nil {
+		codes = append(codes, de.ErrorType().Error())
+	}
+	return codes
+}
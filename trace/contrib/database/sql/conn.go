@@ -0,0 +1,223 @@
+package sql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+const (
+	operationQuery    = "sql.query"
+	operationExec     = "sql.exec"
+	operationPrepare  = "sql.prepare"
+	operationBegin    = "sql.begin"
+	operationCommit   = "sql.commit"
+	operationRollback = "sql.rollback"
+)
+
+var errNamedValueNotSupported = errors.New("aitracer: driver does not support the use of Named Parameters")
+
+// tracedConn wraps driver.Conn. Optional interfaces not implemented by underlying conn return driver.ErrSkip,
+// so that database/sql falls back as it does for underlying conn
+type tracedConn struct {
+	driver.Conn
+	cfg *config
+}
+
+func (c *tracedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := time.Now()
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if pc, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = pc.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	c.cfg.trace(ctx, operationPrepare, query, start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{Stmt: stmt, cfg: c.cfg, query: query}, nil
+}
+
+func (c *tracedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	start := time.Now()
+	var (
+		tx  driver.Tx
+		err error
+	)
+	if bc, ok := c.Conn.(driver.ConnBeginTx); ok {
+		tx, err = bc.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.Conn.Begin()
+	}
+	c.cfg.trace(ctx, operationBegin, "", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedTx{Tx: tx, cfg: c.cfg, ctx: ctx}, nil
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	ec, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	res, err := ec.ExecContext(ctx, query, args)
+	c.cfg.trace(ctx, operationExec, query, start, err)
+	return res, err
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	qc, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := qc.QueryContext(ctx, query, args)
+	c.cfg.trace(ctx, operationQuery, query, start, err)
+	return rows, err
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *tracedConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+func (c *tracedConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+type tracedStmt struct {
+	driver.Stmt
+	cfg   *config
+	query string
+}
+
+func (s *tracedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	start := time.Now()
+	res, err := s.Stmt.Exec(args)
+	s.cfg.trace(context.Background(), operationExec, s.query, start, err)
+	return res, err
+}
+
+func (s *tracedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.Stmt.Query(args)
+	s.cfg.trace(context.Background(), operationQuery, s.query, start, err)
+	return rows, err
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	sc, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		res, err := s.Stmt.Exec(values)
+		s.cfg.trace(ctx, operationExec, s.query, start, err)
+		return res, err
+	}
+	start := time.Now()
+	res, err := sc.ExecContext(ctx, args)
+	s.cfg.trace(ctx, operationExec, s.query, start, err)
+	return res, err
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	sc, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		start := time.Now()
+		rows, err := s.Stmt.Query(values)
+		s.cfg.trace(ctx, operationQuery, s.query, start, err)
+		return rows, err
+	}
+	start := time.Now()
+	rows, err := sc.QueryContext(ctx, args)
+	s.cfg.trace(ctx, operationQuery, s.query, start, err)
+	return rows, err
+}
+
+func (s *tracedStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if nc, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return nc.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+func (s *tracedStmt) ColumnConverter(idx int) driver.ValueConverter {
+	if cc, ok := s.Stmt.(driver.ColumnConverter); ok {
+		return cc.ColumnConverter(idx)
+	}
+	return driver.DefaultParameterConverter
+}
+
+// namedValuesToValues is the same as database/sql does for drivers without context support
+func namedValuesToValues(named []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(named))
+	for i, nv := range named {
+		if len(nv.Name) > 0 {
+			return nil, errNamedValueNotSupported
+		}
+		values[i] = nv.Value
+	}
+	return values, nil
+}
+
+// tracedTx keeps ctx of BeginTx, since Commit and Rollback have no ctx
+type tracedTx struct {
+	driver.Tx
+	cfg *config
+	ctx context.Context
+}
+
+func (t *tracedTx) Commit() error {
+	start := time.Now()
+	err := t.Tx.Commit()
+	t.cfg.trace(t.ctx, operationCommit, "", start, err)
+	return err
+}
+
+func (t *tracedTx) Rollback() error {
+	start := time.Now()
+	err := t.Tx.Rollback()
+	t.cfg.trace(t.ctx, operationRollback, "", start, err)
+	return err
+}
@@ -0,0 +1,231 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+)
+
+var errFake = errors.New("fake error")
+
+// fakeDriver returns errFake for queries starting with "bad"
+type fakeDriver struct {
+	invalid bool
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return &fakeConn{invalid: d.invalid}, nil
+}
+
+type fakeConn struct {
+	invalid bool
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	if isBad(query) {
+		return nil, errFake
+	}
+	return &fakeStmt{query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) { return &fakeTx{}, nil }
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if isBad(query) {
+		return nil, errFake
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if isBad(query) {
+		return nil, errFake
+	}
+	return &fakeRows{}, nil
+}
+
+func (c *fakeConn) IsValid() bool { return !c.invalid }
+
+type fakeStmt struct {
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+
+type fakeTx struct{}
+
+func (tx *fakeTx) Commit() error   { return nil }
+func (tx *fakeTx) Rollback() error { return errFake }
+
+type fakeRows struct{}
+
+func (r *fakeRows) Columns() []string         { return []string{"id"} }
+func (r *fakeRows) Close() error              { return nil }
+func (r *fakeRows) Next([]driver.Value) error { return io.EOF }
+
+func isBad(query string) bool {
+	return len(query) >= 3 && query[:3] == "bad"
+}
+
+// recordingTracer records client spans, other methods of aitracer.Tracer are not used by the wrapper
+type recordingTracer struct {
+	aitracer.Tracer
+	lock  sync.Mutex
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) StartClientSpanFromContext(ctx context.Context, operationName string, opts ...aitracer.StartSpanOption) (aitracer.Span, context.Context) {
+	cfg := &aitracer.StartSpanConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	s := &recordingSpan{operation: operationName, cfg: cfg, tags: map[string]string{}}
+	t.lock.Lock()
+	t.spans = append(t.spans, s)
+	t.lock.Unlock()
+	return s, ctx
+}
+
+func (t *recordingTracer) reset() []*recordingSpan {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	spans := t.spans
+	t.spans = nil
+	return spans
+}
+
+type recordingSpan struct {
+	aitracer.Span
+	operation string
+	cfg       *aitracer.StartSpanConfig
+	tags      map[string]string
+	err       error
+	status    int64
+	finished  bool
+}
+
+func (s *recordingSpan) SetTagString(key string, value string) aitracer.Span {
+	s.tags[key] = value
+	return s
+}
+
+func (s *recordingSpan) RecordError(err error, _ ...aitracer.RecordOption) {
+	s.err = err
+}
+
+func (s *recordingSpan) SetStatus(status int64) {
+	s.status = status
+}
+
+func (s *recordingSpan) Finish() {
+	s.finished = true
+}
+
+func TestTracedDriver(t *testing.T) {
+	tr := &recordingTracer{}
+	db := sql.OpenDB(WrapConnector(&dsnConnector{d: &fakeDriver{}}, tr, aitracer.MySQL,
+		WithEndpoint("127.0.0.1:3306"), WithDBName("db")))
+	defer db.Close()
+	ctx := context.Background()
+
+	assertSpan := func(operation, resource string, err error) *recordingSpan {
+		spans := tr.reset()
+		if !assert.Len(t, spans, 1, operation) {
+			return &recordingSpan{tags: map[string]string{}}
+		}
+		s := spans[0]
+		assert.Equal(t, operation, s.operation)
+		assert.Equal(t, aitracer.MySQL, s.cfg.ClientServiceType)
+		assert.Equal(t, "127.0.0.1:3306/db", s.cfg.ClientService)
+		assert.Equal(t, resource, s.cfg.ClientResource)
+		assert.Equal(t, resource, s.tags["call.resource"])
+		assert.Equal(t, "127.0.0.1:3306", s.tags["peer.address"])
+		assert.Equal(t, "db", s.tags["db.instance"])
+		assert.Equal(t, err, s.err)
+		if err != nil {
+			assert.Equal(t, int64(aitracer.StatusCodeError), s.status)
+		}
+		assert.True(t, s.finished)
+		return s
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT id FROM t WHERE id = 1 # comment")
+	assert.Nil(t, err)
+	rows.Close()
+	s := assertSpan(operationQuery, "SELECT id FROM t WHERE id = ?", nil)
+	assert.Equal(t, "SELECT id FROM t WHERE id = 1 # comment", s.tags[aitracer.DbStatement])
+
+	_, err = db.QueryContext(ctx, "bad query")
+	assert.Equal(t, errFake, err)
+	assertSpan(operationQuery, "bad query", errFake)
+
+	_, err = db.ExecContext(ctx, "UPDATE t SET a = 'x'")
+	assert.Nil(t, err)
+	assertSpan(operationExec, "UPDATE t SET a = ?", nil)
+
+	_, err = db.ExecContext(ctx, "bad exec")
+	assert.Equal(t, errFake, err)
+	assertSpan(operationExec, "bad exec", errFake)
+
+	stmt, err := db.PrepareContext(ctx, "INSERT INTO t VALUES (?, ?)")
+	assert.Nil(t, err)
+	assertSpan(operationPrepare, "INSERT INTO t VALUES (?)", nil)
+	_, err = stmt.ExecContext(ctx, 1, 2)
+	assert.Nil(t, err)
+	assertSpan(operationExec, "INSERT INTO t VALUES (?)", nil)
+	stmt.Close()
+
+	_, err = db.PrepareContext(ctx, "bad prepare")
+	assert.Equal(t, errFake, err)
+	assertSpan(operationPrepare, "bad prepare", errFake)
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.Nil(t, err)
+	s = assertSpan(operationBegin, operationBegin, nil)
+	assert.Empty(t, s.tags[aitracer.DbStatement])
+	assert.Nil(t, tx.Commit())
+	assertSpan(operationCommit, operationCommit, nil)
+
+	tx, err = db.BeginTx(ctx, nil)
+	assert.Nil(t, err)
+	tr.reset()
+	assert.Equal(t, errFake, tx.Rollback())
+	assertSpan(operationRollback, operationRollback, errFake)
+}
+
+func TestTracedConnIsValid(t *testing.T) {
+	c := &tracedConn{Conn: &fakeConn{invalid: true}}
+	assert.False(t, c.IsValid())
+	c = &tracedConn{Conn: &fakeConn{}}
+	assert.True(t, c.IsValid())
+}
+
+type dsnConnector struct {
+	d driver.Driver
+}
+
+func (c *dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.d.Open("")
+}
+
+func (c *dsnConnector) Driver() driver.Driver {
+	return c.d
+}
@@ -0,0 +1,168 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+)
+
+const driverNameSuffix = "-aitracer"
+
+var (
+	registerLock sync.Mutex
+	registered   = make(map[string]bool)
+)
+
+type config struct {
+	tracer   aitracer.Tracer
+	dbType   string
+	endpoint string
+	dbName   string
+}
+
+type Option func(*config)
+
+// WithEndpoint set address of database, like 127.0.0.1:3306
+func WithEndpoint(endpoint string) Option {
+	return func(cfg *config) {
+		cfg.endpoint = endpoint
+	}
+}
+
+// WithDBName set name of database
+func WithDBName(dbName string) Option {
+	return func(cfg *config) {
+		cfg.dbName = dbName
+	}
+}
+
+func newConfig(tracer aitracer.Tracer, dbType string, opts ...Option) *config {
+	cfg := &config{
+		tracer: tracer,
+		dbType: dbType,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func (cfg *config) callService() string {
+	return cfg.endpoint + "/" + cfg.dbName
+}
+
+// Register wraps d and registers it as driverName-aitracer. dbType is client service type of spans, like aitracer.MySQL.
+// Use Open to open database with registered driver. Register the same driverName more than once is a no-op.
+func Register(driverName string, d driver.Driver, tracer aitracer.Tracer, dbType string, opts ...Option) {
+	registerLock.Lock()
+	defer registerLock.Unlock()
+	if registered[driverName] {
+		return
+	}
+	sql.Register(driverName+driverNameSuffix, WrapDriver(d, tracer, dbType, opts...))
+	registered[driverName] = true
+}
+
+// Open opens database with driver registered by Register
+func Open(driverName, dataSourceName string) (*sql.DB, error) {
+	registerLock.Lock()
+	ok := registered[driverName]
+	registerLock.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("aitracer: driver %s is not registered", driverName)
+	}
+	return sql.Open(driverName+driverNameSuffix, dataSourceName)
+}
+
+// WrapDriver returns a driver.Driver which traces Query, Exec, Prepare, Begin, Commit and Rollback of d
+func WrapDriver(d driver.Driver, tracer aitracer.Tracer, dbType string, opts ...Option) driver.Driver {
+	cfg := newConfig(tracer, dbType, opts...)
+	if dc, ok := d.(driver.DriverContext); ok {
+		return &tracedDriverContext{tracedDriver: tracedDriver{Driver: d, cfg: cfg}, dc: dc}
+	}
+	return &tracedDriver{Driver: d, cfg: cfg}
+}
+
+// WrapConnector returns a driver.Connector for sql.OpenDB, which traces connections created by c
+func WrapConnector(c driver.Connector, tracer aitracer.Tracer, dbType string, opts ...Option) driver.Connector {
+	return &tracedConnector{Connector: c, cfg: newConfig(tracer, dbType, opts...)}
+}
+
+type tracedDriver struct {
+	driver.Driver
+	cfg *config
+}
+
+func (d *tracedDriver) Open(name string) (driver.Conn, error) {
+	c, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: c, cfg: d.cfg}, nil
+}
+
+type tracedDriverContext struct {
+	tracedDriver
+	dc driver.DriverContext
+}
+
+func (d *tracedDriverContext) OpenConnector(name string) (driver.Connector, error) {
+	c, err := d.dc.OpenConnector(name)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConnector{Connector: c, cfg: d.cfg, driver: d}, nil
+}
+
+type tracedConnector struct {
+	driver.Connector
+	cfg    *config
+	driver driver.Driver
+}
+
+func (c *tracedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedConn{Conn: conn, cfg: c.cfg}, nil
+}
+
+func (c *tracedConnector) Driver() driver.Driver {
+	if c.driver != nil {
+		return c.driver
+	}
+	return &tracedDriver{Driver: c.Connector.Driver(), cfg: c.cfg}
+}
+
+// trace records operation started at startTime. driver.ErrSkip is not recorded, with which database/sql falls back to other ways
+func (cfg *config) trace(ctx context.Context, operation, query string, startTime time.Time, err error) {
+	if err == driver.ErrSkip {
+		return
+	}
+	resource := operation
+	if query != "" && cfg.dbType == aitracer.MySQL {
+		resource = NormalizeMySQL(query)
+	} else if query != "" {
+		resource = NormalizeSQL(query)
+	}
+	span, _ := cfg.tracer.StartClientSpanFromContext(ctx, operation,
+		aitracer.ClientResourceAs(cfg.dbType, cfg.callService(), resource), aitracer.StartTimeAs(startTime))
+	span.SetTagString("peer.type", cfg.dbType)
+	span.SetTagString("peer.address", cfg.endpoint)
+	span.SetTagString("db.instance", cfg.dbName)
+	span.SetTagString("call.resource", resource)
+	if query != "" {
+		span.SetTagString(aitracer.DbStatement, query)
+	}
+	if err != nil {
+		span.RecordError(err, aitracer.WithErrorKind(aitracer.ErrorKindDbError))
+		span.SetStatus(aitracer.StatusCodeError)
+	}
+	span.Finish()
+}
@@ -0,0 +1,152 @@
+package sql
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const maxResourceLength = 512
+
+var (
+	placeholderListRe = regexp.MustCompile(`\(\s*\?(\s*,\s*\?)*\s*\)`) // (?, ?, ?)
+	tupleListRe       = regexp.MustCompile(`\(\?\)(\s*,\s*\(\?\))+`)   // (?), (?)
+)
+
+// NormalizeSQL turns query into a low-cardinality resource. Comments are removed, literals and placeholders
+// like $1 or :name are replaced by ?, lists like IN (1, 2, 3) and multi-row VALUES are collapsed to (?),
+// whitespaces are collapsed, and the result is truncated to 512 bytes at a rune boundary.
+// # is kept as an operator, like #> of postgres. Use NormalizeMySQL for queries of mysql.
+func NormalizeSQL(query string) string {
+	return normalizeSQL(query, false)
+}
+
+// NormalizeMySQL is the same as NormalizeSQL, except that # starts a comment as mysql does
+func NormalizeMySQL(query string) string {
+	return normalizeSQL(query, true)
+}
+
+func normalizeSQL(query string, hashComment bool) string {
+	var sb strings.Builder
+	sb.Grow(len(query))
+	space := false // pending space
+	writeToken := func(token string) {
+		if space && sb.Len() > 0 {
+			sb.WriteByte(' ')
+		}
+		space = false
+		sb.WriteString(token)
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case isSpace(c):
+			space = true
+			i++
+		case c == '-' && i+1 < len(query) && query[i+1] == '-': // -- comment
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			space = true
+		case c == '#' && hashComment: // mysql comment
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			space = true
+		case c == '/' && i+1 < len(query) && query[i+1] == '*': // /* comment */
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += 2 + end + 2
+			}
+			space = true
+		case c == '\'': // string literal. both '' and \' are treated as escaped quote
+			i++
+			for i < len(query) {
+				if query[i] == '\\' {
+					i += 2
+					continue
+				}
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i += 2
+						continue
+					}
+					break
+				}
+				i++
+			}
+			i++
+			writeToken("?")
+		case c == '"' || c == '`': // quoted identifier, kept as is
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				end = len(query) - i - 1
+			} else {
+				end++
+			}
+			writeToken(query[i : i+end+1])
+			i += end + 1
+		case isDigit(c) || (c == '.' && i+1 < len(query) && isDigit(query[i+1])): // numeric literal, including hex and exponent
+			i++
+			for i < len(query) && (isIdentChar(query[i]) || query[i] == '.' ||
+				((query[i] == '+' || query[i] == '-') && (query[i-1] == 'e' || query[i-1] == 'E'))) {
+				i++
+			}
+			writeToken("?")
+		case c == '$' || c == ':' || c == '@': // placeholders like $1, :name, @p1
+			j := i + 1
+			for j < len(query) && isIdentChar(query[j]) {
+				j++
+			}
+			if j == i+1 || (c == ':' && i > 0 && query[i-1] == ':') { // single char or postgres cast ::
+				writeToken(query[i : i+1])
+				i++
+				continue
+			}
+			if c == ':' && j < len(query) && query[j] == '=' { // := is not a placeholder
+				writeToken(query[i:j])
+				i = j
+				continue
+			}
+			writeToken("?")
+			i = j
+		case isIdentChar(c):
+			j := i + 1
+			for j < len(query) && (isIdentChar(query[j]) || query[j] == '$') {
+				j++
+			}
+			writeToken(query[i:j])
+			i = j
+		default:
+			// punctuation is written without surrounding spaces except those in original query
+			writeToken(query[i : i+1])
+			i++
+		}
+	}
+
+	resource := placeholderListRe.ReplaceAllString(sb.String(), "(?)")
+	resource = tupleListRe.ReplaceAllString(resource, "(?)")
+	if len(resource) > maxResourceLength {
+		n := maxResourceLength
+		for n > 0 && !utf8.RuneStart(resource[n]) {
+			n--
+		}
+		resource = resource[:n]
+	}
+	return resource
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f' || c == '\v'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
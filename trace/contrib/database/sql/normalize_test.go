@@ -0,0 +1,33 @@
+package sql
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeSQL(t *testing.T) {
+	cases := map[string]string{
+		"SELECT * FROM users WHERE id = 1":                                  "SELECT * FROM users WHERE id = ?",
+		"select name from t where name = 'it''s' and x = 'a\\'b'":           "select name from t where name = ? and x = ?",
+		"SELECT a FROM t WHERE id IN (1, 2, 3) -- comment\n AND b = 1.5e-3": "SELECT a FROM t WHERE id IN (?) AND b = ?",
+		"SELECT data #> '{a,b}', data #>> '{c}' FROM t":                     "SELECT data #> ?, data #>> ? FROM t",
+		"INSERT INTO t (a, b) VALUES ($1, $2), ($3, $4)":                    "INSERT INTO t (a, b) VALUES (?)",
+		"UPDATE t SET a = :a, b = @p1 WHERE c = ?":                          "UPDATE t SET a = ?, b = ? WHERE c = ?",
+		"SELECT created_at::date FROM t WHERE x = 0x1F":                     "SELECT created_at::date FROM t WHERE x = ?",
+		"SELECT\n\t t1.col2  FROM\ttable1 t1":                               "SELECT t1.col2 FROM table1 t1",
+	}
+	for query, expected := range cases {
+		assert.Equal(t, expected, NormalizeSQL(query), query)
+	}
+
+	assert.Equal(t, "SELECT `id`, \"name\" FROM t", NormalizeMySQL("/* hint */ SELECT `id`, \"name\" FROM t # mysql comment"))
+
+	long := "SELECT " + strings.Repeat("a,", 1000) + "b FROM t"
+	assert.Len(t, NormalizeSQL(long), maxResourceLength)
+	long = "SELECT " + strings.Repeat("名,", 1000) + "b FROM t" // 3 bytes rune
+	assert.True(t, utf8.ValidString(NormalizeSQL(long)))
+	assert.Len(t, NormalizeSQL(long), maxResourceLength-1)
+}
@@ -82,7 +82,7 @@ func NewMiddleware(tracer aitracer.Tracer, opts ...Option) gin.HandlerFunc {
 			resourceName = cfg.pathNormalizer(c.Request.URL.Path) // pathNormalizer is never nil
 		}
 
-		chainSpanContext, _ := tracer.Extract(aitracer.HTTPHeaders, aitracer.HTTPHeadersCarrier(c.Request.Header))
+		chainSpanContext, _ := tracer.Extract(aitracer.Composite, aitracer.HTTPHeadersCarrier(c.Request.Header))
 		span := tracer.StartServerSpan("request", aitracer.ChildOf(chainSpanContext), aitracer.ServerResourceAs(resourceName))
 		spanContext := span.Context()
 		c.Request = c.Request.WithContext(aitracer.ContextWithSpan(c.Request.Context(), span))
@@ -113,8 +113,9 @@ func NewMiddleware(tracer aitracer.Tracer, opts ...Option) gin.HandlerFunc {
 			// set statusCode. statusCode will display on custom filters
 			span.SetTag(aitracer.HttpStatusCode, status)
 			// distinguish status and statusCode. status is always 0 or 1, and 1 indicates error
-			if status >= http.StatusBadRequest {
+			if tracer.ErrorPolicy().IsHttpError(status) {
 				span.SetStatus(aitracer.StatusCodeError)
+				span.RecordError(aitracer.HttpCodeError(status), aitracer.WithErrorKind(aitracer.ErrorKindHttpCodeError))
 			}
 			for _, err := range c.Errors {
 				span.RecordError(err, aitracer.WithErrorKind(aitracer.ErrorKindBusinessError))
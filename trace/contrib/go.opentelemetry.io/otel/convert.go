@@ -0,0 +1,149 @@
+package otel
+
+import (
+	"strings"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// attribute keys of OpenTelemetry semantic conventions
+const (
+	attrDbSystem        = "db.system"
+	attrMessagingSystem = "messaging.system"
+	attrRpcSystem       = "rpc.system"
+	attrHttpMethod      = "http.method"
+	attrPeerService     = "peer.service"
+	attrNetPeerName     = "net.peer.name"
+	attrDbName          = "db.name"
+
+	exceptionEventName      = "exception"
+	attrExceptionMessage    = "exception.message"
+	attrExceptionStacktrace = "exception.stacktrace"
+)
+
+const spanIDLength = 16
+
+func toStatus(code codes.Code) int64 {
+	if code == codes.Error {
+		return aitracer.StatusCodeError
+	}
+	return aitracer.StatusCodeOK
+}
+
+// clientInfo infers call_service_type and call_service of client span from attributes
+func clientInfo(attrs []attribute.KeyValue) (string, string) {
+	var serviceType, peerService, peerName, dbName string
+	for _, kv := range attrs {
+		switch string(kv.Key) {
+		case attrDbSystem, attrMessagingSystem:
+			serviceType = kv.Value.Emit()
+		case attrRpcSystem:
+			if serviceType == "" {
+				if v := kv.Value.Emit(); v == aitracer.GRPC {
+					serviceType = aitracer.GRPC
+				} else {
+					serviceType = aitracer.RPC
+				}
+			}
+		case attrHttpMethod:
+			if serviceType == "" {
+				serviceType = aitracer.Http
+			}
+		case attrPeerService:
+			peerService = kv.Value.Emit()
+		case attrNetPeerName:
+			peerName = kv.Value.Emit()
+		case attrDbName:
+			dbName = kv.Value.Emit()
+		}
+	}
+	service := peerService
+	if service == "" {
+		service = peerName
+	}
+	if service == "" {
+		service = dbName
+	}
+	return serviceType, service
+}
+
+// fillParams converts attributes into ParamString/ParamInt/ParamFloat. bool and slices are formatted as string
+func fillParams(attrs []attribute.KeyValue, paramString map[string]string, paramInt map[string]int64, paramFloat map[string]float64) {
+	for _, kv := range attrs {
+		key := string(kv.Key)
+		switch kv.Value.Type() {
+		case attribute.INT64:
+			paramInt[key] = kv.Value.AsInt64()
+		case attribute.FLOAT64:
+			paramFloat[key] = kv.Value.AsFloat64()
+		case attribute.STRING:
+			paramString[key] = kv.Value.AsString()
+		case attribute.INVALID:
+		default:
+			paramString[key] = kv.Value.Emit()
+		}
+	}
+}
+
+// toStringMap converts attributes of events and links, all values are formatted as string
+func toStringMap(attrs []attribute.KeyValue) map[string]string {
+	if len(attrs) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(attrs))
+	for _, kv := range attrs {
+		if kv.Value.Type() == attribute.INVALID {
+			continue
+		}
+		m[string(kv.Key)] = kv.Value.Emit()
+	}
+	return m
+}
+
+// toOtelSpanContext converts aitracer.SpanContext. spanId of aitracer is 32 characters, and the last 16 characters are used
+func toOtelSpanContext(sc aitracer.SpanContext) trace.SpanContext {
+	config := trace.SpanContextConfig{}
+	config.TraceID, _ = trace.TraceIDFromHex(strings.ToLower(sc.TraceID()))
+	spanID := strings.ToLower(sc.SpanID())
+	if len(spanID) > spanIDLength {
+		spanID = spanID[len(spanID)-spanIDLength:]
+	}
+	config.SpanID, _ = trace.SpanIDFromHex(spanID)
+	if strategy, _ := sc.Sample(); strategy == aitracer.SampleStrategySampled {
+		config.TraceFlags = trace.FlagsSampled
+	}
+	return trace.NewSpanContext(config)
+}
+
+// remoteSpanContext adapts a remote trace.SpanContext, usually extracted by OpenTelemetry propagators, to aitracer.SpanContext
+type remoteSpanContext struct {
+	sc trace.SpanContext
+}
+
+func (r *remoteSpanContext) TraceID() string {
+	return r.sc.TraceID().String()
+}
+
+func (r *remoteSpanContext) SpanID() string {
+	return r.sc.SpanID().String()
+}
+
+func (r *remoteSpanContext) Sample() (aitracer.SampleStrategy, int) {
+	if r.sc.IsSampled() {
+		return aitracer.SampleStrategySampled, 1
+	}
+	return aitracer.SampleStrategyNotSampled, 0
+}
+
+func (r *remoteSpanContext) ClientSampled() bool {
+	return false
+}
+
+func (r *remoteSpanContext) SampleFlags() aitracer.SampleFlags {
+	return aitracer.SampleFlagsUnknown
+}
+
+func (r *remoteSpanContext) ForeachBaggageItem(func(string, string) bool) {}
@@ -0,0 +1,256 @@
+package otel
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/service_register"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/service_register/register_utils"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultSenderSock         = "/var/run/apminsight/trace.sock"
+	defaultSenderStreamSock   = "/var/run/apminsight/trace_stream.sock"
+	defaultSenderChanSize     = 1024
+	defaultServerRegisterSock = "/var/run/apminsight/comm.sock"
+)
+
+type exporterConfig struct {
+	senderSock         string
+	senderStreamSock   string
+	senderChanSize     int
+	serverRegisterSock string
+	logger             logger.Logger
+}
+
+type ExporterOption func(*exporterConfig)
+
+func WithSenderSock(sock string) ExporterOption {
+	return func(cfg *exporterConfig) {
+		cfg.senderSock = sock
+	}
+}
+
+func WithSenderStreamSock(sock string) ExporterOption {
+	return func(cfg *exporterConfig) {
+		cfg.senderStreamSock = sock
+	}
+}
+
+func WithSenderChanSize(chanSize int) ExporterOption {
+	return func(cfg *exporterConfig) {
+		cfg.senderChanSize = chanSize
+	}
+}
+
+func WithServerRegisterSock(sock string) ExporterOption {
+	return func(cfg *exporterConfig) {
+		cfg.serverRegisterSock = sock
+	}
+}
+
+func WithLogger(l logger.Logger) ExporterOption {
+	return func(cfg *exporterConfig) {
+		cfg.logger = l
+	}
+}
+
+// Exporter is a sdktrace.SpanExporter which converts OpenTelemetry spans into apminsight traces,
+// and sends them to server-agent through trace_sender.TraceSender.
+type Exporter struct {
+	serviceType string
+	service     string
+	containerId string
+	instanceId  string
+
+	lock    sync.RWMutex
+	stopped bool
+
+	traceChan chan *trace_models.Trace
+	sender    *trace_sender.TraceSender
+}
+
+var _ sdktrace.SpanExporter = &Exporter{}
+
+// NewExporter creates and starts an Exporter. serviceType and service are reported as service of all spans,
+// and the service is registered to server-agent the same as aitracer.Tracer. Register is shared by aitracer.Tracer and
+// aiprofiler in the same process, so it is not stopped by Shutdown
+func NewExporter(serviceType, service string, opts ...ExporterOption) *Exporter {
+	cfg := &exporterConfig{
+		senderSock:         defaultSenderSock,
+		senderStreamSock:   defaultSenderStreamSock,
+		senderChanSize:     defaultSenderChanSize,
+		serverRegisterSock: defaultServerRegisterSock,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	e := &Exporter{
+		serviceType: serviceType,
+		service:     service,
+		instanceId:  register_utils.GetInstanceID(),
+		traceChan:   make(chan *trace_models.Trace, cfg.senderChanSize),
+	}
+	info, _ := register_utils.GetInfo()
+	e.containerId = info.ContainerId
+	e.sender = trace_sender.NewTraceSender(cfg.senderSock, cfg.senderStreamSock, e.traceChan, cfg.logger)
+	e.sender.Start()
+	service_register.GetRegister(serviceType, service, service_register.Config{
+		Sock:     cfg.serverRegisterSock,
+		Interval: time.Second * 30,
+		Logger:   cfg.logger,
+	}).Start()
+	return e
+}
+
+// ExportSpans groups spans by traceId. Traces are dropped if sender is busy, the same as aitracer.Tracer
+func (e *Exporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	if e.stopped {
+		return nil
+	}
+	for _, t := range e.convert(spans) {
+		select {
+		case e.traceChan <- t: //non-blocking
+		default:
+		}
+	}
+	return nil
+}
+
+// Shutdown flushes pending traces and stops sender
+func (e *Exporter) Shutdown(ctx context.Context) error {
+	e.lock.Lock()
+	if e.stopped {
+		e.lock.Unlock()
+		return nil
+	}
+	e.stopped = true
+	close(e.traceChan)
+	e.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		e.sender.WaitStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *Exporter) convert(spans []sdktrace.ReadOnlySpan) []*trace_models.Trace {
+	traces := make([]*trace_models.Trace, 0)
+	traceIndex := make(map[trace.TraceID]int)
+	for _, s := range spans {
+		if s == nil {
+			continue
+		}
+		traceID := s.SpanContext().TraceID()
+		idx, ok := traceIndex[traceID]
+		if !ok {
+			idx = len(traces)
+			traceIndex[traceID] = idx
+			// spans exported are sampled by OpenTelemetry, and sample rate is unknown, so each trace represents only itself
+			traces = append(traces, &trace_models.Trace{
+				ServiceType:  e.serviceType,
+				Service:      e.service,
+				ContainerId:  e.containerId,
+				InstanceId:   e.instanceId,
+				TraceId:      traceID.String(),
+				SampleFlags:  int32(aitracer.SampleFlagsServerSampled),
+				SampleWeight: 1,
+			})
+		}
+		if parent := s.Parent(); parent.IsRemote() && parent.IsSampled() {
+			traces[idx].SampleFlags = int32(aitracer.SampleFlagsClientAndServerSampled)
+		}
+		traces[idx].Spans = append(traces[idx].Spans, toSpanModel(s))
+	}
+	return traces
+}
+
+func toSpanModel(s sdktrace.ReadOnlySpan) *trace_models.Span {
+	startTime, endTime := s.StartTime(), s.EndTime()
+	span := &trace_models.Span{
+		SpanId:        s.SpanContext().SpanID().String(),
+		OperationName: s.Name(),
+
+		StartTimeMillisecond: startTime.Unix()*1e3 + int64(startTime.Nanosecond())/1e6,
+		EndTimeMillisecond:   endTime.Unix()*1e3 + int64(endTime.Nanosecond())/1e6,
+		DurationMicroseconds: endTime.Sub(startTime).Microseconds(),
+
+		StartTimeMicrosecond: startTime.Unix()*1e6 + int64(startTime.Nanosecond())/1e3,
+
+		Status: toStatus(s.Status().Code),
+
+		ParamInt:    make(map[string]int64),
+		ParamFloat:  make(map[string]float64),
+		ParamString: make(map[string]string),
+	}
+	if parent := s.Parent(); parent.IsValid() {
+		span.ParentSpanId = parent.SpanID().String()
+	}
+	fillParams(s.Attributes(), span.ParamString, span.ParamInt, span.ParamFloat)
+
+	switch s.SpanKind() {
+	case trace.SpanKindServer, trace.SpanKindConsumer:
+		span.SpanType = trace_models.SpanType_Server
+		span.Resource = s.Name()
+	case trace.SpanKindClient, trace.SpanKindProducer:
+		span.SpanType = trace_models.SpanType_Client
+		span.CallServiceType, span.CallService = clientInfo(s.Attributes())
+		span.CallResource = s.Name()
+	default:
+		span.SpanType = trace_models.SpanType_Common
+	}
+
+	for _, event := range s.Events() {
+		if event.Name != exceptionEventName {
+			span.Events = append(span.Events, &trace_models.SpanEvent{
+				Name:            event.Name,
+				TimeMicrosecond: event.Time.Unix()*1e6 + int64(event.Time.Nanosecond())/1e3,
+				Attributes:      toStringMap(event.Attributes),
+			})
+			continue
+		}
+		errorInfo := &trace_models.ErrorInfo{
+			ErrorKind:      trace_models.ErrorType_UncaughtException,
+			ErrorOccurTime: event.Time.Unix()*1e3 + int64(event.Time.Nanosecond())/1e6,
+		}
+		for _, kv := range event.Attributes {
+			switch string(kv.Key) {
+			case attrExceptionMessage:
+				errorInfo.ErrorMessage = kv.Value.Emit()
+			case attrExceptionStacktrace:
+				errorInfo.ErrorStack = strings.Split(kv.Value.Emit(), "\n")
+			default:
+				if errorInfo.ErrorTags == nil {
+					errorInfo.ErrorTags = make(map[string]string)
+				}
+				errorInfo.ErrorTags[string(kv.Key)] = kv.Value.Emit()
+			}
+		}
+		span.ErrorInfoList = append(span.ErrorInfoList, errorInfo)
+	}
+	for _, link := range s.Links() {
+		span.Links = append(span.Links, &trace_models.SpanLink{
+			TraceId:    link.SpanContext.TraceID().String(),
+			SpanId:     link.SpanContext.SpanID().String(),
+			Attributes: toStringMap(link.Attributes),
+		})
+	}
+	return span
+}
@@ -0,0 +1,94 @@
+package otel
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/trace_sender/trace_models"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestExporterConvert(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	parentID, _ := trace.SpanIDFromHex("a3ce929d0e0e4736")
+	start := time.Now()
+
+	stubs := tracetest.SpanStubs{
+		{
+			Name:        "SELECT users",
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}),
+			Parent:      trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: parentID}),
+			SpanKind:    trace.SpanKindClient,
+			StartTime:   start,
+			EndTime:     start.Add(time.Millisecond),
+			Attributes: []attribute.KeyValue{
+				attribute.String("db.system", "mysql"),
+				attribute.String("db.name", "db"),
+				attribute.Int64("rows", 3),
+				attribute.Float64("ratio", 0.5),
+				attribute.Bool("cached", false),
+			},
+			Events: []sdktrace.Event{
+				{Name: "exception", Time: start, Attributes: []attribute.KeyValue{attribute.String("exception.message", "timeout")}},
+				{Name: "other", Time: start},
+			},
+			Links: []sdktrace.Link{
+				{SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: parentID}), Attributes: []attribute.KeyValue{attribute.Int("n", 1)}},
+			},
+			Status: sdktrace.Status{Code: codes.Error},
+		},
+	}
+
+	e := &Exporter{serviceType: "http", service: "svc"}
+	traces := e.convert(stubs.Snapshots())
+	assert.Equal(t, 1, len(traces))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", traces[0].TraceId)
+	assert.Equal(t, 1, len(traces[0].Spans))
+	assert.Equal(t, int32(aitracer.SampleFlagsServerSampled), traces[0].SampleFlags)
+	assert.Equal(t, int32(1), traces[0].SampleWeight)
+
+	s := traces[0].Spans[0]
+	assert.Equal(t, "00f067aa0ba902b7", s.SpanId)
+	assert.Equal(t, "a3ce929d0e0e4736", s.ParentSpanId)
+	assert.Equal(t, trace_models.SpanType_Client, s.SpanType)
+	assert.Equal(t, "mysql", s.CallServiceType)
+	assert.Equal(t, "db", s.CallService)
+	assert.Equal(t, "SELECT users", s.CallResource)
+	assert.Equal(t, int64(1), s.Status)
+	assert.Equal(t, int64(1000), s.DurationMicroseconds)
+	assert.Equal(t, int64(3), s.ParamInt["rows"])
+	assert.Equal(t, 0.5, s.ParamFloat["ratio"])
+	assert.Equal(t, "false", s.ParamString["cached"])
+	assert.Equal(t, 1, len(s.ErrorInfoList))
+	assert.Equal(t, "timeout", s.ErrorInfoList[0].ErrorMessage)
+	assert.Equal(t, 1, len(s.Events))
+	assert.Equal(t, "other", s.Events[0].Name)
+	assert.Equal(t, 1, len(s.Links))
+	assert.Equal(t, "a3ce929d0e0e4736", s.Links[0].SpanId)
+	assert.Equal(t, "1", s.Links[0].Attributes["n"])
+}
+
+func TestExporterConvertRemoteParent(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	parentID, _ := trace.SpanIDFromHex("a3ce929d0e0e4736")
+	stubs := tracetest.SpanStubs{
+		{
+			Name:        "GET /",
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}),
+			Parent: trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: parentID,
+				TraceFlags: trace.FlagsSampled, Remote: true}),
+			SpanKind: trace.SpanKindServer,
+		},
+	}
+	e := &Exporter{serviceType: "http", service: "svc"}
+	traces := e.convert(stubs.Snapshots())
+	assert.Equal(t, int32(aitracer.SampleFlagsClientAndServerSampled), traces[0].SampleFlags)
+}
@@ -0,0 +1,173 @@
+package otel
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerProvider is a trace.TracerProvider backed by aitracer.Tracer, so that libraries instrumented with
+// OpenTelemetry API record spans into the same traces as aitracer.
+// Span kind server/consumer starts server span, client/producer starts client span, others start common span.
+// SetName is not supported by aitracer and is ignored.
+type TracerProvider struct {
+	tracer aitracer.Tracer
+}
+
+var _ trace.TracerProvider = &TracerProvider{}
+
+func NewTracerProvider(tracer aitracer.Tracer) *TracerProvider {
+	return &TracerProvider{tracer: tracer}
+}
+
+func (p *TracerProvider) Tracer(instrumentationName string, opts ...trace.TracerOption) trace.Tracer {
+	return &bridgeTracer{provider: p}
+}
+
+type bridgeTracer struct {
+	provider *TracerProvider
+}
+
+func (t *bridgeTracer) Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cfg := trace.NewSpanStartConfig(opts...)
+	tracer := t.provider.tracer
+
+	var startOpts []aitracer.StartSpanOption
+	if ts := cfg.Timestamp(); !ts.IsZero() {
+		startOpts = append(startOpts, aitracer.StartTimeAs(ts))
+	}
+	for _, link := range cfg.Links() {
+		if link.SpanContext.IsValid() {
+			startOpts = append(startOpts, aitracer.LinkTo(&remoteSpanContext{sc: link.SpanContext}, toStringMap(link.Attributes)))
+		}
+	}
+	if cfg.NewRoot() {
+		ctx = aitracer.ContextWithSpan(ctx, nil)
+	} else if aitracer.GetSpanFromContext(ctx) == nil {
+		// parent started by other tracer, usually extracted by OpenTelemetry propagators
+		if parent := trace.SpanContextFromContext(ctx); parent.IsValid() {
+			startOpts = append(startOpts, aitracer.ChildOf(&remoteSpanContext{sc: parent}))
+		}
+	}
+
+	var span aitracer.Span
+	switch cfg.SpanKind() {
+	case trace.SpanKindServer, trace.SpanKindConsumer:
+		startOpts = append(startOpts, aitracer.ServerResourceAs(spanName))
+		span, ctx = tracer.StartServerSpanFromContext(ctx, spanName, startOpts...)
+	case trace.SpanKindClient, trace.SpanKindProducer:
+		serviceType, service := clientInfo(cfg.Attributes())
+		startOpts = append(startOpts, aitracer.ClientResourceAs(serviceType, service, spanName))
+		span, ctx = tracer.StartClientSpanFromContext(ctx, spanName, startOpts...)
+	default:
+		span, ctx = tracer.StartSpanFromContext(ctx, spanName, startOpts...)
+	}
+
+	s := &bridgeSpan{
+		span:     span,
+		sc:       toOtelSpanContext(span.Context()),
+		provider: t.provider,
+	}
+	s.SetAttributes(cfg.Attributes()...)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+type bridgeSpan struct {
+	span     aitracer.Span
+	sc       trace.SpanContext
+	provider *TracerProvider
+
+	status int64
+	ended  int64
+}
+
+var _ trace.Span = &bridgeSpan{}
+
+func (s *bridgeSpan) End(options ...trace.SpanEndOption) {
+	if !atomic.CompareAndSwapInt64(&s.ended, 0, 1) {
+		return
+	}
+	cfg := trace.NewSpanEndConfig(options...)
+	s.span.FinishWithOption(aitracer.FinishSpanOption{
+		FinishTime:          cfg.Timestamp(),
+		Status:              atomic.LoadInt64(&s.status),
+		DisablePanicCapture: true, // recover() does not work here since End is not called by defer directly
+	})
+}
+
+// AddEvent records exception events as errors, other events are added to span as they are
+func (s *bridgeSpan) AddEvent(name string, options ...trace.EventOption) {
+	cfg := trace.NewEventConfig(options...)
+	if name != exceptionEventName {
+		s.span.AddEvent(name, toStringMap(cfg.Attributes()), cfg.Timestamp())
+		return
+	}
+	var message, stack string
+	for _, kv := range cfg.Attributes() {
+		switch string(kv.Key) {
+		case attrExceptionMessage:
+			message = kv.Value.Emit()
+		case attrExceptionStacktrace:
+			stack = kv.Value.Emit()
+		}
+	}
+	s.span.RecordError(eventError(message), aitracer.WithErrorKind(aitracer.ErrorKindUncaughtException), aitracer.WithStack(stack))
+}
+
+func (s *bridgeSpan) IsRecording() bool {
+	return atomic.LoadInt64(&s.ended) == 0
+}
+
+func (s *bridgeSpan) RecordError(err error, options ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+	cfg := trace.NewEventConfig(options...)
+	s.span.RecordError(err, aitracer.WithErrorKind(aitracer.ErrorKindUncaughtException), aitracer.WithRecordStack(cfg.StackTrace()))
+}
+
+func (s *bridgeSpan) SpanContext() trace.SpanContext {
+	return s.sc
+}
+
+func (s *bridgeSpan) SetStatus(code codes.Code, description string) {
+	status := toStatus(code)
+	atomic.StoreInt64(&s.status, status)
+	s.span.SetStatus(status)
+}
+
+func (s *bridgeSpan) SetName(name string) {}
+
+func (s *bridgeSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		key := string(attr.Key)
+		switch attr.Value.Type() {
+		case attribute.INT64:
+			s.span.SetTagInt64(key, attr.Value.AsInt64())
+		case attribute.FLOAT64:
+			s.span.SetTagFloat64(key, attr.Value.AsFloat64())
+		case attribute.STRING:
+			s.span.SetTagString(key, attr.Value.AsString())
+		case attribute.INVALID:
+		default:
+			s.span.SetTagString(key, attr.Value.Emit())
+		}
+	}
+}
+
+func (s *bridgeSpan) TracerProvider() trace.TracerProvider {
+	return s.provider
+}
+
+type eventError string
+
+func (e eventError) Error() string {
+	return string(e)
+}
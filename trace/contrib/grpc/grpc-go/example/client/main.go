@@ -28,6 +28,7 @@ func main() {
 	conn, err := grpc.Dial("0.0.0.0:18080",
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
 		grpc.WithUnaryInterceptor(grpc_go.NewUnaryClientInterceptor(tracer)),
+		grpc.WithStreamInterceptor(grpc_go.NewStreamClientInterceptor(tracer)),
 	)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
@@ -40,6 +40,7 @@ func main() {
 	s := grpc.NewServer(
 		grpc.MaxRecvMsgSize(10*1024*1024),
 		grpc.UnaryInterceptor(grpc_go.NewUnaryServerInterceptor(tracer)),
+		grpc.StreamInterceptor(grpc_go.NewStreamServerInterceptor(tracer)),
 	)
 	hello.RegisterGreeterServer(s, helloServer)
 
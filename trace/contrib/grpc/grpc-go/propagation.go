@@ -0,0 +1,63 @@
+package grpc_go
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	tagStatusCode       = "grpc.status_code"
+	tagSentMessages     = "grpc.sent.messages"
+	tagSentBytes        = "grpc.sent.bytes"
+	tagReceivedMessages = "grpc.received.messages"
+	tagReceivedBytes    = "grpc.received.bytes"
+	tagWireDurationUs   = "grpc.wire.duration_us"
+)
+
+// extractIncoming extracts parent spanContext from incoming metadata
+func extractIncoming(ctx context.Context, tracer aitracer.Tracer) aitracer.SpanContext {
+	// get metaInfo from context
+	meta, _ := metadata.FromIncomingContext(ctx)
+	metaCopy := meta.Copy()
+
+	// extract spanContext from metaInfo
+	parentSpanContext, _ := tracer.Extract(aitracer.Composite, aitracer.HTTPHeadersCarrier(metaCopy))
+	return parentSpanContext
+}
+
+// injectOutgoing injects spanContext of span into outgoing metadata of ctx
+func injectOutgoing(ctx context.Context, tracer aitracer.Tracer, span aitracer.Span) context.Context {
+	meta, _ := metadata.FromOutgoingContext(ctx)
+	metaCopy := meta.Copy()
+	// format spanCtx to header
+	header := make(http.Header)
+	_ = tracer.Inject(span.Context(), aitracer.Composite, aitracer.HTTPHeadersCarrier(header))
+	// set buf in metainfo
+	for k, v := range header {
+		metaCopy.Set(k, v...)
+	}
+	// set metainfo to context
+	return metadata.NewOutgoingContext(ctx, metaCopy)
+}
+
+// setStatus sets status code of rpc, and records err of errorKind if code is error by policy. It returns true if rpc fails
+func setStatus(span aitracer.Span, policy *aitracer.ErrorPolicy, err error, errorKind aitracer.ErrorKind) bool {
+	if err == nil {
+		span.SetTagInt64(tagStatusCode, int64(codes.OK))
+		return false
+	}
+	s, _ := status.FromError(err)
+	span.SetTagInt64(tagStatusCode, int64(s.Code()))
+	if !policy.IsRpcError(strconv.Itoa(int(s.Code())), s.Code().String()) {
+		return false
+	}
+	span.SetStatus(aitracer.StatusCodeError)
+	span.RecordError(err, aitracer.WithErrorKind(errorKind))
+	return true
+}
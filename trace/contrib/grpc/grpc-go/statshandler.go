@@ -0,0 +1,113 @@
+package grpc_go
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+	"google.golang.org/grpc/stats"
+)
+
+type rpcStateKey struct{}
+
+// rpcState is created in TagRPC and updated by HandleRPC. payloads of streaming rpc may be handled concurrently
+type rpcState struct {
+	span    aitracer.Span
+	counter messageCounter
+}
+
+// statsHandler traces rpc by stats.Handler, which works for both unary and streaming calls.
+// Sizes are wire length of messages, and grpc.wire.duration_us is time between rpc begins and ends in transport
+type statsHandler struct {
+	tracer   aitracer.Tracer
+	cfg      *Config
+	isClient bool
+}
+
+// NewServerStatsHandler returns a stats.Handler for grpc.StatsHandler server option. Do not use it with server interceptors at the same time
+func NewServerStatsHandler(tracer aitracer.Tracer, opts ...Option) stats.Handler {
+	return newStatsHandler(tracer, false, opts...)
+}
+
+// NewClientStatsHandler returns a stats.Handler for grpc.WithStatsHandler dial option. Do not use it with client interceptors at the same time
+func NewClientStatsHandler(tracer aitracer.Tracer, opts ...Option) stats.Handler {
+	return newStatsHandler(tracer, true, opts...)
+}
+
+func newStatsHandler(tracer aitracer.Tracer, isClient bool, opts ...Option) *statsHandler {
+	cfg := newDefaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &statsHandler{
+		tracer:   tracer,
+		cfg:      cfg,
+		isClient: isClient,
+	}
+}
+
+func (h *statsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	var span aitracer.Span
+	if h.isClient {
+		// target is unknown in stats.Handler, use service name of method instead
+		span, ctx = h.tracer.StartClientSpanFromContext(ctx, "grpc.call", aitracer.ClientResourceAs(h.cfg.targetServiceType, serviceOfMethod(info.FullMethodName), info.FullMethodName))
+		ctx = injectOutgoing(ctx, h.tracer, span)
+	} else {
+		span = h.tracer.StartServerSpan("grpc.called", aitracer.ChildOf(extractIncoming(ctx, h.tracer)), aitracer.ServerResourceAs(info.FullMethodName))
+		if h.cfg.baggageSetter != nil {
+			for k, v := range h.cfg.baggageSetter(ctx) {
+				span.SetBaggageItem(k, v)
+			}
+		}
+		ctx = aitracer.ContextWithSpan(ctx, span)
+	}
+	return context.WithValue(ctx, rpcStateKey{}, &rpcState{span: span})
+}
+
+func (h *statsHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
+	state, ok := ctx.Value(rpcStateKey{}).(*rpcState)
+	if !ok {
+		return
+	}
+	switch s := rs.(type) {
+	case *stats.InPayload:
+		atomic.AddInt64(&state.counter.receivedMessages, 1)
+		atomic.AddInt64(&state.counter.receivedBytes, int64(s.WireLength))
+	case *stats.OutPayload:
+		atomic.AddInt64(&state.counter.sentMessages, 1)
+		atomic.AddInt64(&state.counter.sentBytes, int64(s.WireLength))
+	case *stats.End:
+		span := state.span
+		state.counter.setTags(span)
+		span.SetTagInt64(tagWireDurationUs, s.EndTime.Sub(s.BeginTime).Microseconds())
+		errorKind := aitracer.ErrorKindBusinessError
+		if h.isClient {
+			errorKind = aitracer.ErrorKindExternalServiceError
+		}
+		failed := setStatus(span, h.tracer.ErrorPolicy(), s.Error, errorKind)
+		opt := aitracer.FinishSpanOption{
+			FinishTime:          s.EndTime,
+			DisablePanicCapture: true, // recover() does not work here since HandleRPC is not called by defer directly
+		}
+		if failed {
+			opt.Status = aitracer.StatusCodeError // status is overwritten by FinishWithOption
+		}
+		span.FinishWithOption(opt)
+	}
+}
+
+func (h *statsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (h *statsHandler) HandleConn(context.Context, stats.ConnStats) {}
+
+// serviceOfMethod returns service of full method like /package.Service/Method
+func serviceOfMethod(fullMethod string) string {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(fullMethod, "/"); i >= 0 {
+		return fullMethod[:i]
+	}
+	return fullMethod
+}
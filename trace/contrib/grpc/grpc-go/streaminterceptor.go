@@ -0,0 +1,205 @@
+package grpc_go
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// messageCounter counts messages and their sizes. size is proto size of message, not including grpc framing
+type messageCounter struct {
+	sentMessages     int64
+	sentBytes        int64
+	receivedMessages int64
+	receivedBytes    int64
+}
+
+func (c *messageCounter) sent(msg interface{}) {
+	atomic.AddInt64(&c.sentMessages, 1)
+	atomic.AddInt64(&c.sentBytes, int64(messageSize(msg)))
+}
+
+func (c *messageCounter) received(msg interface{}) {
+	atomic.AddInt64(&c.receivedMessages, 1)
+	atomic.AddInt64(&c.receivedBytes, int64(messageSize(msg)))
+}
+
+func (c *messageCounter) setTags(span aitracer.Span) {
+	span.SetTagInt64(tagSentMessages, atomic.LoadInt64(&c.sentMessages))
+	span.SetTagInt64(tagSentBytes, atomic.LoadInt64(&c.sentBytes))
+	span.SetTagInt64(tagReceivedMessages, atomic.LoadInt64(&c.receivedMessages))
+	span.SetTagInt64(tagReceivedBytes, atomic.LoadInt64(&c.receivedBytes))
+}
+
+func messageSize(msg interface{}) int {
+	switch m := msg.(type) {
+	case proto.Message:
+		return proto.Size(m)
+	case interface{ Size() int }: // gogo protobuf
+		return m.Size()
+	}
+	return 0
+}
+
+func NewStreamServerInterceptor(tracer aitracer.Tracer, opts ...Option) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		cfg := newDefaultConfig()
+		for _, opt := range opts {
+			opt(cfg)
+		}
+		ctx := ss.Context()
+
+		// extract spanContext from metaInfo
+		parentSpanContext := extractIncoming(ctx, tracer)
+
+		// start server span from parentSpanContext
+		span := tracer.StartServerSpan("grpc.called", aitracer.ChildOf(parentSpanContext), aitracer.ServerResourceAs(info.FullMethod))
+		defer span.Finish()
+
+		if cfg.baggageSetter != nil {
+			for k, v := range cfg.baggageSetter(ctx) {
+				span.SetBaggageItem(k, v)
+			}
+		}
+
+		// use stream with ctxWithSpan to handle request
+		stream := &serverStream{
+			ServerStream: ss,
+			ctx:          aitracer.ContextWithSpan(ctx, span),
+		}
+		err := handler(srv, stream)
+		stream.counter.setTags(span)
+		setStatus(span, tracer.ErrorPolicy(), err, aitracer.ErrorKindBusinessError)
+		return err
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	counter messageCounter
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil {
+		s.counter.sent(m)
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil {
+		s.counter.received(m)
+	}
+	return err
+}
+
+// NewStreamClientInterceptor traces streaming calls. Span is finished when stream ends, which is
+// RecvMsg returning io.EOF or error, the only response received for non-server-streaming calls, or ctx done.
+// Make sure to receive until io.EOF or cancel ctx, otherwise span is never finished.
+func NewStreamClientInterceptor(tracer aitracer.Tracer, opts ...Option) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cfg := newDefaultConfig()
+		for _, opt := range opts {
+			opt(cfg)
+		}
+
+		span, ctxWithSpan := tracer.StartClientSpanFromContext(ctx, "grpc.call", aitracer.ClientResourceAs(cfg.targetServiceType, cc.Target(), method))
+
+		// propagate
+		ctxWithSpan = injectOutgoing(ctxWithSpan, tracer, span)
+
+		cs, err := streamer(ctxWithSpan, desc, cc, method, callOpts...)
+		if err != nil {
+			setStatus(span, tracer.ErrorPolicy(), err, aitracer.ErrorKindExternalServiceError)
+			span.Finish()
+			return nil, err
+		}
+		stream := &clientStream{
+			ClientStream:  cs,
+			span:          span,
+			policy:        tracer.ErrorPolicy(),
+			serverStreams: desc.ServerStreams,
+			done:          make(chan struct{}),
+		}
+		go func() {
+			select {
+			case <-ctx.Done():
+				stream.finish(ctx.Err())
+			case <-stream.done:
+			}
+		}()
+		return stream, nil
+	}
+}
+
+type clientStream struct {
+	grpc.ClientStream
+	span          aitracer.Span
+	policy        *aitracer.ErrorPolicy
+	serverStreams bool
+	counter       messageCounter
+
+	finishOnce sync.Once
+	done       chan struct{}
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.counter.sent(m)
+	} else if err != io.EOF { // io.EOF means stream is terminated, whose status is returned by RecvMsg
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.counter.received(m)
+		if !s.serverStreams {
+			s.finish(nil)
+		}
+	} else if err == io.EOF {
+		s.finish(nil)
+	} else {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) CloseSend() error {
+	err := s.ClientStream.CloseSend()
+	if err != nil {
+		s.finish(err)
+	}
+	return err
+}
+
+func (s *clientStream) finish(err error) {
+	s.finishOnce.Do(func() {
+		close(s.done)
+		s.counter.setTags(s.span)
+		failed := setStatus(s.span, s.policy, err, aitracer.ErrorKindExternalServiceError)
+		opt := aitracer.FinishSpanOption{
+			DisablePanicCapture: true, // recover() does not work here since finish is not called by defer directly
+		}
+		if failed {
+			opt.Status = aitracer.StatusCodeError // status is overwritten by FinishWithOption
+		}
+		s.span.FinishWithOption(opt)
+	})
+}
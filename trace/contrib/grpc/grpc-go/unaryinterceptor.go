@@ -2,17 +2,11 @@ package grpc_go
 
 import (
 	"context"
-	"net/http"
 
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
-// currently only supported unaryInterceptor
-
 type Config struct {
 	targetServiceType string
 	baggageSetter     func(ctx context.Context) map[string]string // get key-value pair from ctx and set in span baggage
@@ -48,12 +42,8 @@ func NewUnaryServerInterceptor(tracer aitracer.Tracer, opts ...Option) grpc.Unar
 			opt(cfg)
 		}
 
-		// get metaInfo from context
-		meta, _ := metadata.FromIncomingContext(ctx)
-		metaCopy := meta.Copy()
-
 		// extract spanContext from metaInfo
-		parentSpanContext, _ := tracer.Extract(aitracer.HTTPHeaders, aitracer.HTTPHeadersCarrier(metaCopy))
+		parentSpanContext := extractIncoming(ctx, tracer)
 
 		// start server span from parentSpanContext
 		span := tracer.StartServerSpan("grpc.called", aitracer.ChildOf(parentSpanContext), aitracer.ServerResourceAs(info.FullMethod))
@@ -70,14 +60,7 @@ func NewUnaryServerInterceptor(tracer aitracer.Tracer, opts ...Option) grpc.Unar
 
 		// use ctxWithSpan to handler request
 		resp, err = handler(ctxWithSpan, req)
-		if err != nil {
-			s, _ := status.FromError(err)
-			span.SetStatus(aitracer.StatusCodeError)
-			span.SetTagInt64("grpc.status_code", int64(s.Code()))
-			span.RecordError(err, aitracer.WithErrorKind(aitracer.ErrorKindBusinessError))
-		} else {
-			span.SetTagInt64("grpc.status_code", int64(codes.OK))
-		}
+		setStatus(span, tracer.ErrorPolicy(), err, aitracer.ErrorKindBusinessError)
 		return
 	}
 }
@@ -94,28 +77,11 @@ func NewUnaryClientInterceptor(tracer aitracer.Tracer, opts ...Option) grpc.Unar
 		defer span.Finish()
 
 		// propagate
-		meta, _ := metadata.FromOutgoingContext(ctx)
-		metaCopy := meta.Copy()
-		// format spanCtx to header
-		header := make(http.Header)
-		_ = tracer.Inject(span.Context(), aitracer.HTTPHeaders, aitracer.HTTPHeadersCarrier(header))
-		// set buf in metainfo
-		for k, v := range header {
-			metaCopy.Set(k, v...)
-		}
-		// set metainfo to context
-		ctxWithSpan = metadata.NewOutgoingContext(ctxWithSpan, metaCopy)
+		ctxWithSpan = injectOutgoing(ctxWithSpan, tracer, span)
 
 		// call remote service with ctxWithSpan
 		err := invoker(ctxWithSpan, method, req, reply, cc, callOpts...)
-		if err != nil {
-			s, _ := status.FromError(err)
-			span.SetStatus(aitracer.StatusCodeError)
-			span.SetTagInt64("grpc.status_code", int64(s.Code()))
-			span.RecordError(err, aitracer.WithErrorKind(aitracer.ErrorKindExternalServiceError))
-		} else {
-			span.SetTagInt64("grpc.status_code", int64(codes.OK))
-		}
+		setStatus(span, tracer.ErrorPolicy(), err, aitracer.ErrorKindExternalServiceError)
 		return err
 	}
 }
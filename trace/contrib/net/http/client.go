@@ -106,7 +106,7 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (res *http.Response, err er
 		}
 	}
 
-	_ = rt.tracer.Inject(span.Context(), aitracer.HTTPHeaders, aitracer.HTTPHeadersCarrier(req.Header))
+	_ = rt.tracer.Inject(span.Context(), aitracer.Composite, aitracer.HTTPHeadersCarrier(req.Header))
 	res, err = rt.base.RoundTrip(req.WithContext(ctx))
 	if err != nil {
 		span.SetTag(aitracer.HttpStatusCode, http.StatusInternalServerError)
@@ -116,12 +116,13 @@ func (rt *roundTripper) RoundTrip(req *http.Request) (res *http.Response, err er
 		})
 	} else {
 		span.SetTag(aitracer.HttpStatusCode, res.StatusCode)
-		if res.StatusCode == http.StatusOK {
-			span.Finish()
-		} else {
+		if rt.tracer.ErrorPolicy().IsHttpError(res.StatusCode) {
+			span.RecordError(aitracer.HttpCodeError(res.StatusCode), aitracer.WithErrorKind(aitracer.ErrorKindHttpCodeError))
 			span.FinishWithOption(aitracer.FinishSpanOption{
-				Status: int64(res.StatusCode),
+				Status: aitracer.StatusCodeError,
 			})
+		} else {
+			span.Finish()
 		}
 	}
 	return res, err
@@ -2,6 +2,7 @@ package sarama
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/Shopify/sarama"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer"
@@ -21,14 +22,7 @@ func WrapHandler(handler func(ctx context.Context, data []byte), tracer aitracer
 		}
 
 		// get tracing from msg header
-		m := make(map[string][]string)
-		for _, h := range msg.Headers {
-			k := string(h.Key)
-			v := string(h.Value)
-			m[k] = append(m[k], v)
-		}
-
-		parentSpanContext, _ := tracer.Extract(aitracer.HTTPHeaders, aitracer.HTTPHeadersCarrier(m))
+		parentSpanContext := extractMessage(tracer, msg)
 
 		span := tracer.StartServerSpan("kafka.consume", aitracer.ChildOf(parentSpanContext), aitracer.ServerResourceAs("consume"))
 		defer span.Finish()
@@ -51,3 +45,73 @@ func WrapHandler(handler func(ctx context.Context, data []byte), tracer aitracer
 		handler(ctxWithSpan, msg.Value)
 	}
 }
+
+// WrapBatchHandler wrap func(ctx context.Context, msgs []*sarama.ConsumerMessage) for consumers processing messages in batch.
+// A single serverSpan is generated for the batch, which links to producer of each message instead of being child of any of them
+func WrapBatchHandler(handler func(ctx context.Context, msgs []*sarama.ConsumerMessage), tracer aitracer.Tracer, opts ...Option) func(msgs []*sarama.ConsumerMessage) {
+	return func(msgs []*sarama.ConsumerMessage) {
+		if tracer == nil {
+			panic("tracer is nil")
+		}
+
+		cfg := newDefaultConfig()
+		for _, opt := range opts {
+			opt(cfg)
+		}
+
+		startOpts := []aitracer.StartSpanOption{aitracer.ServerResourceAs("consume")}
+		topic := ""
+		for _, msg := range msgs {
+			if msg == nil {
+				continue
+			}
+			topic = msg.Topic
+			if sc := extractMessage(tracer, msg); sc != nil {
+				startOpts = append(startOpts, aitracer.LinkTo(sc, map[string]string{
+					"mq.topic":     msg.Topic,
+					"mq.partition": strconv.FormatInt(int64(msg.Partition), 10),
+					"mq.offset":    strconv.FormatInt(msg.Offset, 10),
+				}))
+			}
+		}
+
+		span := tracer.StartServerSpan("kafka.consume", startOpts...)
+		defer span.Finish()
+
+		span.SetTagString("mq.type", "kafka")
+		span.SetTagString("mq.topic", topic)
+		span.SetTagInt64("mq.batch_size", int64(len(msgs)))
+
+		// extra tag
+		for k, v := range cfg.additionalTags {
+			span.SetTagString(k, v)
+		}
+
+		// in this case, we regard mq as consumer's upstream service
+		span.SetTagString("from_service_type", "kafka")
+		span.SetTagString("from_service", topic)
+
+		// set span in context
+		ctxWithSpan := aitracer.ContextWithSpan(context.Background(), span)
+
+		handler(ctxWithSpan, msgs)
+	}
+}
+
+// extractMessage extracts spanContext of producer from msg header. nil is returned if msg is not traced
+func extractMessage(tracer aitracer.Tracer, msg *sarama.ConsumerMessage) aitracer.SpanContext {
+	m := make(map[string][]string)
+	for _, h := range msg.Headers {
+		if h == nil {
+			continue
+		}
+		k := string(h.Key)
+		v := string(h.Value)
+		m[k] = append(m[k], v)
+	}
+	sc, err := tracer.Extract(aitracer.HTTPHeaders, aitracer.HTTPHeadersCarrier(m))
+	if err != nil {
+		return nil
+	}
+	return sc
+}
@@ -11,7 +11,15 @@ const (
 )
 
 const (
-	GoErrorType = "go.error_type"
+	GoErrorType      = "go.error_type"
+	DirectCauseCrash = "direct_cause_crash"
+)
+
+// pprof labels set to goroutines of server spans by tracer, which are kept in cpu and goroutine profiles
+const (
+	PprofLabelTraceID  = "trace_id"
+	PprofLabelSpanID   = "span_id"
+	PprofLabelResource = "resource"
 )
 
 const (
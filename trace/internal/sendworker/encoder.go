@@ -78,6 +78,18 @@ func EncodePreAllocated(payload []byte, headerBytes []byte) []byte {
 	return payload
 }
 
+// DecodeBody returns body of payload encoded by Encode or EncodePreAllocated
+func DecodeBody(payload []byte) []byte {
+	if len(payload) < CommonPrefixLen+Version1DataPrefixLen || payload[6] != Version1 {
+		return nil
+	}
+	bodyStart := CommonPrefixLen + int(binary.LittleEndian.Uint32(payload[7:11]))
+	if bodyStart > len(payload) {
+		return nil
+	}
+	return payload[bodyStart:]
+}
+
 func FormatMap(m map[string]string) []byte {
 	b := bytes.NewBuffer(nil)
 	for k, v := range m {
@@ -0,0 +1,181 @@
+package sendworker
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/volcengine/apminsight-server-sdk-go/internal/httputil"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
+	"github.com/volcengine/apminsight-server-sdk-go/trace/internal/agentless_adapter"
+)
+
+const (
+	stopIntervalHeaderKey = "X-ByteAPM-Stop"
+
+	defaultHTTPTimeout     = 5 * time.Second
+	defaultRetryCount      = 2
+	defaultBackoffInterval = time.Second
+	maxBackoffInterval     = 30 * time.Second
+)
+
+// HTTPWorker sends batches to collector directly, bypassing server-agent.
+// A batch is a sequence of size-prefixed(4 bytes little endian) items, which is unpacked and wrapped into one message by wrap.
+// Message is compressed by gzip. Requests failed by network error, status 429 or 5xx are retried, Retry-After header is respected.
+// If collector responds with X-ByteAPM-Stop header (in minutes), batches are dropped until the interval passes.
+type HTTPWorker struct {
+	logger logger.Logger
+
+	client *http.Client
+	url    string
+
+	msgType string
+	stats   *selfstats.Pipeline
+	wrap    func(items [][]byte) ([]byte, error)
+
+	retryCount      int
+	backoffInterval time.Duration
+	stopUntil       time.Time
+
+	sleep func(time.Duration) // for test
+}
+
+func NewHTTPWorker(msgType, schema, host, path string, timeout time.Duration, wrap func([][]byte) ([]byte, error), l logger.Logger) *HTTPWorker {
+	if l == nil {
+		l = &logger.NoopLogger{}
+	}
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+	return &HTTPWorker{
+		logger:          l,
+		client:          &http.Client{Timeout: timeout},
+		url:             fmt.Sprintf("%s://%s/%s", schema, host, strings.TrimPrefix(path, "/")),
+		msgType:         msgType,
+		stats:           selfstats.Get(msgType),
+		wrap:            wrap,
+		retryCount:      defaultRetryCount,
+		backoffInterval: defaultBackoffInterval,
+		sleep:           time.Sleep,
+	}
+}
+
+func (w *HTTPWorker) BatchSend(data []byte, _ []byte) {
+	if len(data) == 0 {
+		return
+	}
+	items := SplitSizePrefixed(data)
+	if len(items) == 0 {
+		return
+	}
+	if time.Now().Before(w.stopUntil) {
+		w.logger.Debug("[HTTPWorker] drop batch %s. stopped by collector until %v", w.msgType, w.stopUntil)
+		w.stats.AddDropped(int64(len(items)))
+		return
+	}
+	w.logger.Debug("[HTTPWorker] send batch %s. data size %d bytes", w.msgType, len(data))
+
+	msg, err := w.wrap(items)
+	if err != nil {
+		w.logger.Error("[HTTPWorker] wrap %s err %v", w.msgType, err)
+		w.stats.AddEncodeError(int64(len(items)))
+		return
+	}
+	body, err := httputil.Gzip(msg)
+	if err != nil {
+		w.logger.Error("[HTTPWorker] compress %s err %v", w.msgType, err)
+		w.stats.AddEncodeError(int64(len(items)))
+		return
+	}
+
+	backoff := w.backoffInterval
+	for i := 0; ; i++ {
+		retryAfter, err := w.post(body)
+		if err == nil {
+			w.stats.AddSent(int64(len(items)))
+			return
+		}
+		w.stats.AddConnError(1)
+		if retryAfter < 0 || i >= w.retryCount {
+			w.logger.Error("[HTTPWorker] send %s err %v", w.msgType, err)
+			w.stats.AddDropped(int64(len(items)))
+			return
+		}
+		if retryAfter == 0 {
+			retryAfter = backoff
+		}
+		w.sleep(retryAfter)
+		if backoff *= 2; backoff > maxBackoffInterval {
+			backoff = maxBackoffInterval
+		}
+	}
+}
+
+// post returns negative duration if request should not be retried
+func (w *HTTPWorker) post(body []byte) (time.Duration, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return -1, err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set(agentless_adapter.AppKey, agentless_adapter.GetAppKey())
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if stopMinute, _ := strconv.ParseInt(resp.Header.Get(stopIntervalHeaderKey), 10, 64); stopMinute > 0 {
+		w.stopUntil = time.Now().Add(time.Duration(stopMinute) * time.Minute)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+	err = fmt.Errorf("status code %d", resp.StatusCode)
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return httputil.RetryAfter(resp, maxBackoffInterval), err
+	}
+	return -1, err
+}
+
+func (w *HTTPWorker) CloseConn() {
+	w.client.CloseIdleConnections()
+}
+
+// CountSizePrefixed returns number of complete size-prefixed items in data
+func CountSizePrefixed(data []byte) int {
+	n := 0
+	for len(data) >= 4 {
+		size := int(binary.LittleEndian.Uint32(data[0:4]))
+		if size > len(data)-4 {
+			break
+		}
+		n++
+		data = data[4+size:]
+	}
+	return n
+}
+
+// SplitSizePrefixed splits data of size-prefixed items. Truncated item at the end is discarded
+func SplitSizePrefixed(data []byte) [][]byte {
+	var items [][]byte
+	for len(data) >= 4 {
+		size := int(binary.LittleEndian.Uint32(data[0:4]))
+		if size > len(data)-4 {
+			break
+		}
+		items = append(items, data[4:4+size])
+		data = data[4+size:]
+	}
+	return items
+}
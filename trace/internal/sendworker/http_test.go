@@ -0,0 +1,81 @@
+package sendworker
+
+import (
+	"compress/gzip"
+	"encoding/binary"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
+)
+
+func sizePrefixed(items ...string) []byte {
+	var data []byte
+	for _, item := range items {
+		size := make([]byte, 4)
+		binary.LittleEndian.PutUint32(size, uint32(len(item)))
+		data = append(data, size...)
+		data = append(data, item...)
+	}
+	return data
+}
+
+func TestSplitSizePrefixed(t *testing.T) {
+	data := sizePrefixed("a", "", "bcd")
+	items := SplitSizePrefixed(data)
+	assert.Equal(t, [][]byte{[]byte("a"), {}, []byte("bcd")}, items)
+
+	// truncated item is discarded
+	items = SplitSizePrefixed(data[:len(data)-1])
+	assert.Equal(t, [][]byte{[]byte("a"), {}}, items)
+}
+
+func TestHTTPWorker(t *testing.T) {
+	var (
+		requests int
+		body     string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/server_collect/trace_collect", r.URL.Path)
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		if requests == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		gr, err := gzip.NewReader(r.Body)
+		assert.Nil(t, err)
+		b, _ := ioutil.ReadAll(gr)
+		body = string(b)
+		w.Header().Set(stopIntervalHeaderKey, "1")
+	}))
+	defer srv.Close()
+
+	var slept []time.Duration
+	wrap := func(items [][]byte) ([]byte, error) {
+		var s []string
+		for _, item := range items {
+			s = append(s, string(item))
+		}
+		return []byte(strings.Join(s, ",")), nil
+	}
+	w := NewHTTPWorker("trace", "http", strings.TrimPrefix(srv.URL, "http://"), "/server_collect/trace_collect", time.Second, wrap, nil)
+	w.sleep = func(d time.Duration) { slept = append(slept, d) }
+
+	prev := selfstats.Trace.Snapshot()
+	w.BatchSend(sizePrefixed("a", "b"), nil)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, []time.Duration{3 * time.Second}, slept)
+	assert.Equal(t, "a,b", body)
+
+	// stopped by X-ByteAPM-Stop
+	w.BatchSend(sizePrefixed("c"), nil)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, selfstats.Snapshot{Sent: 2, Dropped: 1, ConnError: 1}, selfstats.Trace.Snapshot().Sub(prev))
+}
@@ -0,0 +1,254 @@
+package sendworker
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	spillSegmentSuffix = ".seg"
+
+	defaultSpillSegmentBytes = 4 << 20   // 4MB
+	defaultSpillMaxBytes     = 256 << 20 // 256MB
+	defaultSpillMaxAge       = time.Hour
+
+	maxReplayBatches = 16 // spilled batches replayed before each send, so that a send is not blocked long by backlog
+)
+
+var ErrSpillBufferClosed = errors.New("spill buffer closed")
+
+type SpillConfig struct {
+	Dir          string
+	MaxBytes     int64         // total size of segments, the oldest segments are evicted when exceeded
+	SegmentBytes int64         // size of one segment file
+	MaxAge       time.Duration // segments not written for MaxAge are evicted
+}
+
+type segment struct {
+	seq     uint64
+	size    int64
+	modTime time.Time
+}
+
+// SpillBuffer is a bounded on-disk FIFO queue. Records are appended to segment files under Dir,
+// a new segment is created when the current one reaches SegmentBytes.
+// Segments left by previous process are loaded by NewSpillBuffer, so records survive restart.
+// Records of a partially replayed segment may be replayed again after restart.
+type SpillBuffer struct {
+	lock sync.Mutex
+
+	dir          string
+	maxBytes     int64
+	segmentBytes int64
+	maxAge       time.Duration
+
+	segments   []*segment // in order. the last one is being written if w is not nil
+	w          *os.File
+	totalBytes int64
+	nextSeq    uint64
+	closed     bool
+
+	readBuf    []byte // content of segments[0] being replayed
+	readOffset int
+}
+
+func NewSpillBuffer(cfg SpillConfig) (*SpillBuffer, error) {
+	if cfg.Dir == "" {
+		return nil, errors.New("spill dir is empty")
+	}
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultSpillMaxBytes
+	}
+	if cfg.SegmentBytes <= 0 {
+		cfg.SegmentBytes = defaultSpillSegmentBytes
+	}
+	if cfg.SegmentBytes > cfg.MaxBytes {
+		cfg.SegmentBytes = cfg.MaxBytes
+	}
+	if cfg.MaxAge <= 0 {
+		cfg.MaxAge = defaultSpillMaxAge
+	}
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, err
+	}
+	b := &SpillBuffer{
+		dir:          cfg.Dir,
+		maxBytes:     cfg.MaxBytes,
+		segmentBytes: cfg.SegmentBytes,
+		maxAge:       cfg.MaxAge,
+	}
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *SpillBuffer) load() error {
+	files, err := ioutil.ReadDir(b.dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if f.IsDir() || !strings.HasSuffix(f.Name(), spillSegmentSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(f.Name(), spillSegmentSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		b.segments = append(b.segments, &segment{seq: seq, size: f.Size(), modTime: f.ModTime()})
+		b.totalBytes += f.Size()
+	}
+	sort.Slice(b.segments, func(i, j int) bool {
+		return b.segments[i].seq < b.segments[j].seq
+	})
+	if n := len(b.segments); n > 0 {
+		b.nextSeq = b.segments[n-1].seq + 1
+	}
+	b.evict()
+	return nil
+}
+
+// Push appends a record. Oldest segments are evicted if total size exceeds MaxBytes
+func (b *SpillBuffer) Push(record []byte) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.closed {
+		return ErrSpillBufferClosed
+	}
+	size := int64(4 + len(record))
+	if b.w != nil {
+		if current := b.segments[len(b.segments)-1]; current.size > 0 && current.size+size > b.segmentBytes {
+			b.closeWriter()
+		}
+	}
+	if b.w == nil {
+		if err := b.newSegment(); err != nil {
+			return err
+		}
+	}
+	data := make([]byte, size)
+	binary.LittleEndian.PutUint32(data[0:4], uint32(len(record)))
+	copy(data[4:], record)
+	n, err := b.w.Write(data)
+	current := b.segments[len(b.segments)-1]
+	current.size += int64(n)
+	current.modTime = time.Now()
+	b.totalBytes += int64(n)
+	if err != nil {
+		b.closeWriter() // record may be truncated, which is discarded when replaying
+	}
+	b.evict()
+	return err
+}
+
+// Take removes at most n oldest records and returns them in order, so that they are sent without holding lock.
+// Records failed to be sent should be pushed again. drained is true if no record is left.
+// Records of a partially taken segment may be taken again after restart.
+func (b *SpillBuffer) Take(n int) (records [][]byte, drained bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.evict()
+	for len(b.segments) > 0 && len(records) < n {
+		if b.readBuf == nil {
+			if b.w != nil && len(b.segments) == 1 {
+				b.closeWriter() // take segment being written, following records go to a new segment
+			}
+			data, err := ioutil.ReadFile(b.segmentPath(b.segments[0].seq))
+			if err != nil {
+				b.removeOldest()
+				continue
+			}
+			b.readBuf, b.readOffset = data, 0
+		}
+		if b.readOffset+4 <= len(b.readBuf) {
+			size := int(binary.LittleEndian.Uint32(b.readBuf[b.readOffset : b.readOffset+4]))
+			if end := b.readOffset + 4 + size; end <= len(b.readBuf) { // otherwise truncated
+				records = append(records, b.readBuf[b.readOffset+4:end:end])
+				b.readOffset = end
+			} else {
+				b.readOffset = len(b.readBuf)
+			}
+		}
+		if b.readOffset+4 > len(b.readBuf) {
+			b.removeOldest()
+		}
+	}
+	return records, len(b.segments) == 0
+}
+
+// Empty returns true if there is no record to replay
+func (b *SpillBuffer) Empty() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return len(b.segments) == 0
+}
+
+// Size returns total size of segments in bytes
+func (b *SpillBuffer) Size() int64 {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.totalBytes
+}
+
+// Close closes current segment. Records are kept on disk and loaded by next NewSpillBuffer
+func (b *SpillBuffer) Close() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.closed = true
+	b.closeWriter()
+}
+
+func (b *SpillBuffer) newSegment() error {
+	seq := b.nextSeq
+	f, err := os.OpenFile(b.segmentPath(seq), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	b.nextSeq++
+	b.w = f
+	b.segments = append(b.segments, &segment{seq: seq, modTime: time.Now()})
+	return nil
+}
+
+func (b *SpillBuffer) closeWriter() {
+	if b.w != nil {
+		_ = b.w.Close()
+		b.w = nil
+	}
+}
+
+// evict removes expired segments and oldest segments beyond MaxBytes. segment being written is kept unless expired
+func (b *SpillBuffer) evict() {
+	expireTime := time.Now().Add(-b.maxAge)
+	for len(b.segments) > 0 && b.segments[0].modTime.Before(expireTime) {
+		b.removeOldest()
+	}
+	for len(b.segments) > 1 && b.totalBytes > b.maxBytes {
+		b.removeOldest()
+	}
+}
+
+func (b *SpillBuffer) removeOldest() {
+	if len(b.segments) == 1 {
+		b.closeWriter()
+	}
+	oldest := b.segments[0]
+	_ = os.Remove(b.segmentPath(oldest.seq))
+	b.totalBytes -= oldest.size
+	b.segments = b.segments[1:]
+	b.readBuf, b.readOffset = nil, 0
+}
+
+func (b *SpillBuffer) segmentPath(seq uint64) string {
+	return filepath.Join(b.dir, fmt.Sprintf("%020d%s", seq, spillSegmentSuffix))
+}
@@ -0,0 +1,73 @@
+package sendworker
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func takeAll(b *SpillBuffer) []string {
+	var records []string
+	taken, _ := b.Take(math.MaxInt32)
+	for _, r := range taken {
+		records = append(records, string(r))
+	}
+	return records
+}
+
+func TestSpillBuffer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	b, err := NewSpillBuffer(SpillConfig{Dir: dir, MaxBytes: 1 << 20, SegmentBytes: 16})
+	assert.Nil(t, err)
+	assert.True(t, b.Empty())
+	for _, r := range []string{"aaaa", "bbbb", "cccc", "dddd"} {
+		assert.Nil(t, b.Push([]byte(r)))
+	}
+	assert.Equal(t, int64(32), b.Size())
+
+	// take the first record only
+	records, drained := b.Take(1)
+	assert.False(t, drained)
+	assert.Equal(t, [][]byte{[]byte("aaaa")}, records)
+
+	// records are loaded after restart
+	b.Close()
+	b, err = NewSpillBuffer(SpillConfig{Dir: dir, MaxBytes: 1 << 20, SegmentBytes: 16})
+	assert.Nil(t, err)
+	assert.Nil(t, b.Push([]byte("eeee")))
+	// first segment is partially taken before restart, so aaaa is taken again
+	assert.Equal(t, []string{"aaaa", "bbbb", "cccc", "dddd", "eeee"}, takeAll(b))
+	assert.True(t, b.Empty())
+	assert.Equal(t, int64(0), b.Size())
+	b.Close()
+	assert.Equal(t, ErrSpillBufferClosed, b.Push([]byte("ffff")))
+}
+
+func TestSpillBufferEvict(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spill")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// oldest segments are evicted when exceeding MaxBytes
+	b, err := NewSpillBuffer(SpillConfig{Dir: dir, MaxBytes: 16, SegmentBytes: 8})
+	assert.Nil(t, err)
+	for _, r := range []string{"aaaa", "bbbb", "cccc"} {
+		assert.Nil(t, b.Push([]byte(r)))
+	}
+	assert.Equal(t, []string{"bbbb", "cccc"}, takeAll(b))
+
+	// expired segments are evicted
+	b.maxAge = time.Millisecond
+	assert.Nil(t, b.Push([]byte("dddd")))
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, takeAll(b))
+	assert.True(t, b.Empty())
+	b.Close()
+}
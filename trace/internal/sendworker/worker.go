@@ -4,6 +4,7 @@ import (
 	"net"
 	"strings"
 
+	"github.com/volcengine/apminsight-server-sdk-go/internal/selfstats"
 	"github.com/volcengine/apminsight-server-sdk-go/trace/aitracer/logger"
 )
 
@@ -19,6 +20,9 @@ type DatagramWorker struct {
 	conn net.Conn
 
 	msgType string
+	stats   *selfstats.Pipeline
+
+	spill *SpillBuffer // batches failed to send are spilled if set
 }
 
 func NewDatagramWorker(msgType, sock string, l logger.Logger) *DatagramWorker {
@@ -29,6 +33,7 @@ func NewDatagramWorker(msgType, sock string, l logger.Logger) *DatagramWorker {
 		logger:  l,
 		sock:    sock,
 		msgType: msgType,
+		stats:   selfstats.Get(msgType),
 	}
 }
 
@@ -37,25 +42,75 @@ func (w *DatagramWorker) BatchSend(data []byte, _ []byte) {
 		return
 	}
 	w.logger.Debug("[DatagramWorker] send batch %s. data size %d bytes", w.msgType, len(data))
+	items := int64(CountSizePrefixed(data))
 
 	if w.conn == nil {
 		w.newConn()
 		if w.conn == nil {
+			w.spillBatch(data, items)
 			return
 		}
 	}
+	if !w.replay() { // keep order, spilled batches are sent first
+		w.spillBatch(data, items)
+		return
+	}
 	_, err := w.conn.Write(data)
 	if err != nil {
 		w.logger.Error("[DatagramWorker] send %s err %v", w.msgType, err)
+		w.stats.AddConnError(1)
 		w.conn.Close()
 		w.conn = nil
+		w.spillBatch(data, items)
+		return
+	}
+	w.stats.AddSent(items)
+}
+
+// SetSpillBuffer enables spilling batches to disk when server-agent is unreachable.
+// Spilled batches are replayed in order once connection recovers
+func (w *DatagramWorker) SetSpillBuffer(spill *SpillBuffer) {
+	w.spill = spill
+}
+
+func (w *DatagramWorker) spillBatch(data []byte, items int64) {
+	if w.spill == nil {
+		w.stats.AddDropped(items)
+		return
+	}
+	if err := w.spill.Push(data); err != nil {
+		w.logger.Error("[DatagramWorker] spill %s err %v", w.msgType, err)
+		w.stats.AddDropped(items)
+	}
+}
+
+// replay sends at most maxReplayBatches spilled batches, and returns true if all have been sent
+func (w *DatagramWorker) replay() bool {
+	if w.spill == nil {
+		return true
+	}
+	batches, drained := w.spill.Take(maxReplayBatches)
+	for i, data := range batches {
+		if _, err := w.conn.Write(data); err != nil {
+			w.logger.Error("[DatagramWorker] replay %s err %v", w.msgType, err)
+			w.stats.AddConnError(1)
+			_ = w.conn.Close()
+			w.conn = nil
+			for _, left := range batches[i:] {
+				w.spillBatch(left, int64(CountSizePrefixed(left)))
+			}
+			return false
+		}
+		w.stats.AddSent(int64(CountSizePrefixed(data)))
 	}
+	return drained
 }
 
 func (w *DatagramWorker) newConn() {
 	conn, err := net.Dial("unixgram", w.sock)
 	if err != nil {
 		w.logger.Error("[DatagramWorker] create conn %s err %v", w.sock, err)
+		w.stats.AddConnError(1)
 		return
 	}
 	w.conn = conn
@@ -74,6 +129,9 @@ type StreamWorker struct {
 	conn net.Conn
 
 	msgType string
+	stats   *selfstats.Pipeline
+
+	spill *SpillBuffer // encoded batches failed to send are spilled if set
 }
 
 func NewStreamWorker(msgType, sock string, l logger.Logger) *StreamWorker {
@@ -84,6 +142,7 @@ func NewStreamWorker(msgType, sock string, l logger.Logger) *StreamWorker {
 		logger:  l,
 		sock:    sock,
 		msgType: msgType,
+		stats:   selfstats.Get(msgType),
 	}
 }
 
@@ -94,35 +153,87 @@ func (w *StreamWorker) BatchSend(data []byte, tags []byte) {
 	w.logger.Debug("[StreamWorker] send batch %s %d", w.msgType, len(data))
 
 	payload := EncodePreAllocated(data, tags)
+	items := int64(CountSizePrefixed(DecodeBody(payload)))
 
 	if w.conn == nil {
 		w.newConn()
 		if w.conn == nil {
+			w.spillPayload(payload, items)
 			return
 		}
 	}
+	if !w.replay() { // keep order, spilled batches are sent first
+		w.spillPayload(payload, items)
+		return
+	}
 
 	_, err := w.conn.Write(payload)
 	if err != nil && strings.Contains(strings.ToLower(err.Error()), "broken pipe") { // retry when server-agent has closed connection
 		w.logger.Info("[StreamWorker] connection has been closed by remote. retrying send %s", w.msgType)
+		w.stats.AddConnError(1)
 		w.CloseConn() // close current connection
 		w.newConn()   // try to establish a new conn
 		if w.conn == nil {
+			w.spillPayload(payload, items)
 			return
 		}
 		_, err = w.conn.Write(payload) //retry once
 	}
 	if err != nil {
 		w.logger.Error("[StreamWorker] send %s err %v", w.msgType, err)
+		w.stats.AddConnError(1)
 		_ = w.conn.Close()
 		w.conn = nil
+		w.spillPayload(payload, items)
+		return
+	}
+	w.stats.AddSent(items)
+}
+
+// SetSpillBuffer enables spilling encoded batches to disk when server-agent is unreachable.
+// Spilled batches are replayed in order once connection recovers
+func (w *StreamWorker) SetSpillBuffer(spill *SpillBuffer) {
+	w.spill = spill
+}
+
+func (w *StreamWorker) spillPayload(payload []byte, items int64) {
+	if w.spill == nil {
+		w.stats.AddDropped(items)
+		return
+	}
+	if err := w.spill.Push(payload); err != nil {
+		w.logger.Error("[StreamWorker] spill %s err %v", w.msgType, err)
+		w.stats.AddDropped(items)
+	}
+}
+
+// replay sends at most maxReplayBatches spilled payloads, and returns true if all have been sent
+func (w *StreamWorker) replay() bool {
+	if w.spill == nil {
+		return true
+	}
+	payloads, drained := w.spill.Take(maxReplayBatches)
+	for i, payload := range payloads {
+		if _, err := w.conn.Write(payload); err != nil {
+			w.logger.Error("[StreamWorker] replay %s err %v", w.msgType, err)
+			w.stats.AddConnError(1)
+			_ = w.conn.Close()
+			w.conn = nil
+			for _, left := range payloads[i:] {
+				w.spillPayload(left, int64(CountSizePrefixed(DecodeBody(left))))
+			}
+			return false
+		}
+		w.stats.AddSent(int64(CountSizePrefixed(DecodeBody(payload))))
 	}
+	return drained
 }
 
 func (w *StreamWorker) newConn() {
 	conn, err := net.Dial("unix", w.sock)
 	if err != nil {
 		w.logger.Error("[StreamWorker] create tcp conn %s err %v", w.sock, err)
+		w.stats.AddConnError(1)
 		return
 	}
 	w.conn = conn
@@ -48,6 +48,7 @@ type Register struct {
 	stopChan chan struct{}
 	wg       sync.WaitGroup
 	runOnce  sync.Once
+	stopOnce sync.Once
 }
 
 var (
@@ -106,8 +107,11 @@ func (r *Register) Start() {
 	)
 }
 
+// Stop can be called multiple times, since Register is shared by tracer, profiler and exporter
 func (r *Register) Stop() {
-	close(r.stopChan)
+	r.stopOnce.Do(func() {
+		close(r.stopChan)
+	})
 	r.wg.Wait()
 }
 
@@ -59,7 +59,7 @@ func (x *SampleStrategy) UnmarshalJSON(data []byte) error {
 	return nil
 }
 func (SampleStrategy) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{0}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{0}
 }
 
 type ServiceSettings struct {
@@ -71,7 +71,7 @@ func (m *ServiceSettings) Reset()         { *m = ServiceSettings{} }
 func (m *ServiceSettings) String() string { return proto.CompactTextString(m) }
 func (*ServiceSettings) ProtoMessage()    {}
 func (*ServiceSettings) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{0}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{0}
 }
 func (m *ServiceSettings) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -126,7 +126,7 @@ func (m *Settings) Reset()         { *m = Settings{} }
 func (m *Settings) String() string { return proto.CompactTextString(m) }
 func (*Settings) ProtoMessage()    {}
 func (*Settings) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{1}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{1}
 }
 func (m *Settings) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -198,7 +198,7 @@ func (m *Db) Reset()         { *m = Db{} }
 func (m *Db) String() string { return proto.CompactTextString(m) }
 func (*Db) ProtoMessage()    {}
 func (*Db) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{2}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{2}
 }
 func (m *Db) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -243,7 +243,7 @@ func (m *Trace) Reset()         { *m = Trace{} }
 func (m *Trace) String() string { return proto.CompactTextString(m) }
 func (*Trace) ProtoMessage()    {}
 func (*Trace) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{3}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{3}
 }
 func (m *Trace) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -296,7 +296,7 @@ func (m *BusinessError) Reset()         { *m = BusinessError{} }
 func (m *BusinessError) String() string { return proto.CompactTextString(m) }
 func (*BusinessError) ProtoMessage()    {}
 func (*BusinessError) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{4}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{4}
 }
 func (m *BusinessError) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -355,7 +355,7 @@ func (m *CrashClazz) Reset()         { *m = CrashClazz{} }
 func (m *CrashClazz) String() string { return proto.CompactTextString(m) }
 func (*CrashClazz) ProtoMessage()    {}
 func (*CrashClazz) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{5}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{5}
 }
 func (m *CrashClazz) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -406,7 +406,7 @@ func (m *ProfileSettings) Reset()         { *m = ProfileSettings{} }
 func (m *ProfileSettings) String() string { return proto.CompactTextString(m) }
 func (*ProfileSettings) ProtoMessage()    {}
 func (*ProfileSettings) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{6}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{6}
 }
 func (m *ProfileSettings) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -460,7 +460,7 @@ func (m *Profile) Reset()         { *m = Profile{} }
 func (m *Profile) String() string { return proto.CompactTextString(m) }
 func (*Profile) ProtoMessage()    {}
 func (*Profile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{7}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{7}
 }
 func (m *Profile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -567,15 +567,16 @@ func (m *Profile) GetPtConfigList() []*ProfileTypeConfig {
 }
 
 type TraceSample struct {
-	Strategy SampleStrategy `protobuf:"varint,1,req,name=strategy,enum=settings_models.SampleStrategy" json:"strategy"`
-	Value    float64        `protobuf:"fixed64,2,opt,name=value" json:"value"`
+	Strategy SampleStrategy     `protobuf:"varint,1,req,name=strategy,enum=settings_models.SampleStrategy" json:"strategy"`
+	Value    float64            `protobuf:"fixed64,2,opt,name=value" json:"value"`
+	Rules    []*TraceSampleRule `protobuf:"bytes,3,rep,name=rules" json:"rules,omitempty"`
 }
 
 func (m *TraceSample) Reset()         { *m = TraceSample{} }
 func (m *TraceSample) String() string { return proto.CompactTextString(m) }
 func (*TraceSample) ProtoMessage()    {}
 func (*TraceSample) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{8}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{8}
 }
 func (m *TraceSample) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -618,6 +619,13 @@ func (m *TraceSample) GetValue() float64 {
 	return 0
 }
 
+func (m *TraceSample) GetRules() []*TraceSampleRule {
+	if m != nil {
+		return m.Rules
+	}
+	return nil
+}
+
 type TraceCustomInstrument struct {
 	ClassName            string   `protobuf:"bytes,1,req,name=class_name,json=className" json:"class_name"`
 	MethodName           []string `protobuf:"bytes,2,rep,name=method_name,json=methodName" json:"method_name,omitempty"`
@@ -632,7 +640,7 @@ func (m *TraceCustomInstrument) Reset()         { *m = TraceCustomInstrument{} }
 func (m *TraceCustomInstrument) String() string { return proto.CompactTextString(m) }
 func (*TraceCustomInstrument) ProtoMessage()    {}
 func (*TraceCustomInstrument) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{9}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{9}
 }
 func (m *TraceCustomInstrument) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -711,19 +719,20 @@ func (m *TraceCustomInstrument) GetCollectedObjectDepth() int32 {
 }
 
 type TriggerCondition struct {
-	Key     string  `protobuf:"bytes,1,req,name=key" json:"key"`
-	Label   string  `protobuf:"bytes,2,req,name=label" json:"label"`
-	Compare string  `protobuf:"bytes,3,req,name=compare" json:"compare"`
-	Op      string  `protobuf:"bytes,4,req,name=op" json:"op"`
-	Value   float64 `protobuf:"fixed64,5,req,name=value" json:"value"`
-	Unit    string  `protobuf:"bytes,6,req,name=unit" json:"unit"`
+	Key      string  `protobuf:"bytes,1,req,name=key" json:"key"`
+	Label    string  `protobuf:"bytes,2,req,name=label" json:"label"`
+	Compare  string  `protobuf:"bytes,3,req,name=compare" json:"compare"`
+	Op       string  `protobuf:"bytes,4,req,name=op" json:"op"`
+	Value    float64 `protobuf:"fixed64,5,req,name=value" json:"value"`
+	Unit     string  `protobuf:"bytes,6,req,name=unit" json:"unit"`
+	AndGroup int32   `protobuf:"varint,7,opt,name=and_group,json=andGroup" json:"and_group"`
 }
 
 func (m *TriggerCondition) Reset()         { *m = TriggerCondition{} }
 func (m *TriggerCondition) String() string { return proto.CompactTextString(m) }
 func (*TriggerCondition) ProtoMessage()    {}
 func (*TriggerCondition) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{10}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{10}
 }
 func (m *TriggerCondition) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -794,6 +803,13 @@ func (m *TriggerCondition) GetUnit() string {
 	return ""
 }
 
+func (m *TriggerCondition) GetAndGroup() int32 {
+	if m != nil {
+		return m.AndGroup
+	}
+	return 0
+}
+
 type ProfileTypeConfig struct {
 	ProfileType     string `protobuf:"bytes,1,req,name=profile_type,json=profileType" json:"profile_type"`
 	DurationSeconds int64  `protobuf:"varint,2,opt,name=duration_seconds,json=durationSeconds" json:"duration_seconds"`
@@ -804,7 +820,7 @@ func (m *ProfileTypeConfig) Reset()         { *m = ProfileTypeConfig{} }
 func (m *ProfileTypeConfig) String() string { return proto.CompactTextString(m) }
 func (*ProfileTypeConfig) ProtoMessage()    {}
 func (*ProfileTypeConfig) Descriptor() ([]byte, []int) {
-	return fileDescriptor_settings_eea2cc344d5887f1, []int{11}
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{11}
 }
 func (m *ProfileTypeConfig) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -854,6 +870,82 @@ func (m *ProfileTypeConfig) GetIsSnapshot() bool {
 	return false
 }
 
+type TraceSampleRule struct {
+	Resource  string            `protobuf:"bytes,1,opt,name=resource" json:"resource"`
+	Operation string            `protobuf:"bytes,2,opt,name=operation" json:"operation"`
+	Baggage   map[string]string `protobuf:"bytes,3,rep,name=baggage" json:"baggage,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Strategy  SampleStrategy    `protobuf:"varint,4,req,name=strategy,enum=settings_models.SampleStrategy" json:"strategy"`
+	Value     float64           `protobuf:"fixed64,5,opt,name=value" json:"value"`
+}
+
+func (m *TraceSampleRule) Reset()         { *m = TraceSampleRule{} }
+func (m *TraceSampleRule) String() string { return proto.CompactTextString(m) }
+func (*TraceSampleRule) ProtoMessage()    {}
+func (*TraceSampleRule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_settings_a4c741de559a9bcb, []int{12}
+}
+func (m *TraceSampleRule) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TraceSampleRule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TraceSampleRule.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalTo(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (dst *TraceSampleRule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceSampleRule.Merge(dst, src)
+}
+func (m *TraceSampleRule) XXX_Size() int {
+	return m.Size()
+}
+func (m *TraceSampleRule) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceSampleRule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceSampleRule proto.InternalMessageInfo
+
+func (m *TraceSampleRule) GetResource() string {
+	if m != nil {
+		return m.Resource
+	}
+	return ""
+}
+
+func (m *TraceSampleRule) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+func (m *TraceSampleRule) GetBaggage() map[string]string {
+	if m != nil {
+		return m.Baggage
+	}
+	return nil
+}
+
+func (m *TraceSampleRule) GetStrategy() SampleStrategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return SampleStrategy_ALL
+}
+
+func (m *TraceSampleRule) GetValue() float64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*ServiceSettings)(nil), "settings_models.ServiceSettings")
 	proto.RegisterType((*Settings)(nil), "settings_models.Settings")
@@ -867,6 +959,8 @@ func init() {
 	proto.RegisterType((*TraceCustomInstrument)(nil), "settings_models.TraceCustomInstrument")
 	proto.RegisterType((*TriggerCondition)(nil), "settings_models.TriggerCondition")
 	proto.RegisterType((*ProfileTypeConfig)(nil), "settings_models.ProfileTypeConfig")
+	proto.RegisterType((*TraceSampleRule)(nil), "settings_models.TraceSampleRule")
+	proto.RegisterMapType((map[string]string)(nil), "settings_models.TraceSampleRule.BaggageEntry")
 	proto.RegisterEnum("settings_models.SampleStrategy", SampleStrategy_name, SampleStrategy_value)
 }
 func (m *ServiceSettings) Marshal() (dAtA []byte, err error) {
@@ -1276,6 +1370,18 @@ func (m *TraceSample) MarshalTo(dAtA []byte) (int, error) {
 	i++
 	encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Value))))
 	i += 8
+	if len(m.Rules) > 0 {
+		for _, msg := range m.Rules {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintSettings(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	return i, nil
 }
 
@@ -1397,6 +1503,9 @@ func (m *TriggerCondition) MarshalTo(dAtA []byte) (int, error) {
 	i++
 	i = encodeVarintSettings(dAtA, i, uint64(len(m.Unit)))
 	i += copy(dAtA[i:], m.Unit)
+	dAtA[i] = 0x38
+	i++
+	i = encodeVarintSettings(dAtA, i, uint64(m.AndGroup))
 	return i, nil
 }
 
@@ -1433,6 +1542,56 @@ func (m *ProfileTypeConfig) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
+func (m *TraceSampleRule) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TraceSampleRule) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	dAtA[i] = 0xa
+	i++
+	i = encodeVarintSettings(dAtA, i, uint64(len(m.Resource)))
+	i += copy(dAtA[i:], m.Resource)
+	dAtA[i] = 0x12
+	i++
+	i = encodeVarintSettings(dAtA, i, uint64(len(m.Operation)))
+	i += copy(dAtA[i:], m.Operation)
+	if len(m.Baggage) > 0 {
+		for k, _ := range m.Baggage {
+			dAtA[i] = 0x1a
+			i++
+			v := m.Baggage[k]
+			mapSize := 1 + len(k) + sovSettings(uint64(len(k))) + 1 + len(v) + sovSettings(uint64(len(v)))
+			i = encodeVarintSettings(dAtA, i, uint64(mapSize))
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintSettings(dAtA, i, uint64(len(k)))
+			i += copy(dAtA[i:], k)
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintSettings(dAtA, i, uint64(len(v)))
+			i += copy(dAtA[i:], v)
+		}
+	}
+	dAtA[i] = 0x20
+	i++
+	i = encodeVarintSettings(dAtA, i, uint64(m.Strategy))
+	dAtA[i] = 0x29
+	i++
+	encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Value))))
+	i += 8
+	return i, nil
+}
+
 func encodeVarintSettings(dAtA []byte, offset int, v uint64) int {
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1623,6 +1782,12 @@ func (m *TraceSample) Size() (n int) {
 	_ = l
 	n += 1 + sovSettings(uint64(m.Strategy))
 	n += 9
+	if len(m.Rules) > 0 {
+		for _, e := range m.Rules {
+			l = e.Size()
+			n += 1 + l + sovSettings(uint64(l))
+		}
+	}
 	return n
 }
 
@@ -1670,6 +1835,7 @@ func (m *TriggerCondition) Size() (n int) {
 	n += 9
 	l = len(m.Unit)
 	n += 1 + l + sovSettings(uint64(l))
+	n += 1 + sovSettings(uint64(m.AndGroup))
 	return n
 }
 
@@ -1686,6 +1852,29 @@ func (m *ProfileTypeConfig) Size() (n int) {
 	return n
 }
 
+func (m *TraceSampleRule) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Resource)
+	n += 1 + l + sovSettings(uint64(l))
+	l = len(m.Operation)
+	n += 1 + l + sovSettings(uint64(l))
+	if len(m.Baggage) > 0 {
+		for k, v := range m.Baggage {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovSettings(uint64(len(k))) + 1 + len(v) + sovSettings(uint64(len(v)))
+			n += mapEntrySize + 1 + sovSettings(uint64(mapEntrySize))
+		}
+	}
+	n += 1 + sovSettings(uint64(m.Strategy))
+	n += 9
+	return n
+}
+
 func sovSettings(x uint64) (n int) {
 	for {
 		n++
@@ -3019,6 +3208,37 @@ func (m *TraceSample) Unmarshal(dAtA []byte) error {
 			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
 			m.Value = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rules", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Rules = append(m.Rules, &TraceSampleRule{})
+			if err := m.Rules[len(m.Rules)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSettings(dAtA[iNdEx:])
@@ -3472,6 +3692,25 @@ func (m *TriggerCondition) Unmarshal(dAtA []byte) error {
 			m.Unit = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 			hasFields[0] |= uint64(0x00000020)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AndGroup", wireType)
+			}
+			m.AndGroup = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AndGroup |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipSettings(dAtA[iNdEx:])
@@ -3634,6 +3873,267 @@ func (m *ProfileTypeConfig) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *TraceSampleRule) Unmarshal(dAtA []byte) error {
+	var hasFields [1]uint64
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowSettings
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TraceSampleRule: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TraceSampleRule: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resource", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Resource = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Operation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Operation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Baggage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthSettings
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Baggage == nil {
+				m.Baggage = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowSettings
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSettings
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthSettings
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowSettings
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= (uint64(b) & 0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthSettings
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipSettings(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthSettings
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Baggage[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Strategy", wireType)
+			}
+			m.Strategy = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowSettings
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Strategy |= (SampleStrategy(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			hasFields[0] |= uint64(0x00000001)
+		case 5:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipSettings(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthSettings
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+	if hasFields[0]&uint64(0x00000001) == 0 {
+		return github_com_gogo_protobuf_proto.NewRequiredNotSetError("strategy")
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipSettings(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -3739,79 +4239,87 @@ var (
 	ErrIntOverflowSettings   = fmt.Errorf("proto: integer overflow")
 )
 
-func init() { proto.RegisterFile("settings.proto", fileDescriptor_settings_eea2cc344d5887f1) }
-
-var fileDescriptor_settings_eea2cc344d5887f1 = []byte{
-	// 1123 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4d, 0x6f, 0x23, 0x45,
-	0x10, 0xcd, 0x8c, 0xe3, 0x4d, 0x5c, 0xfe, 0x4c, 0x6f, 0x36, 0x1a, 0x3e, 0xe4, 0x98, 0x89, 0x76,
-	0x31, 0xcb, 0x12, 0x90, 0x25, 0x2e, 0x70, 0x00, 0xc7, 0x89, 0x84, 0x45, 0xc2, 0x86, 0xb1, 0x05,
-	0xc7, 0x61, 0x3c, 0xd3, 0xeb, 0x34, 0xcc, 0xd7, 0x76, 0xb7, 0x83, 0xbc, 0xbf, 0x82, 0x03, 0xff,
-	0x80, 0x2b, 0x27, 0x2e, 0xdc, 0x38, 0xef, 0x71, 0x8f, 0x48, 0x48, 0x08, 0x25, 0x7f, 0x04, 0x75,
-	0xf7, 0xf4, 0x78, 0xc6, 0xce, 0x4a, 0x7b, 0xf3, 0xbc, 0x7a, 0x55, 0xdd, 0xd5, 0xf5, 0x5e, 0x19,
-	0x5a, 0x0c, 0x73, 0x4e, 0xe2, 0x39, 0x3b, 0x4e, 0x69, 0xc2, 0x13, 0xd4, 0xd6, 0xdf, 0x6e, 0x94,
-	0x04, 0x38, 0x64, 0xf6, 0x73, 0x68, 0x4f, 0x30, 0xbd, 0x26, 0x3e, 0x9e, 0x64, 0x11, 0xf4, 0x3e,
-	0x34, 0x98, 0x82, 0xdc, 0xd8, 0x8b, 0xb0, 0x65, 0xf4, 0xcc, 0x7e, 0xed, 0x64, 0xfb, 0xe5, 0xbf,
-	0x87, 0x5b, 0x4e, 0x3d, 0x8b, 0x7c, 0xe3, 0x45, 0x18, 0x7d, 0x0a, 0xbb, 0xba, 0x9c, 0x65, 0xf6,
-	0xcc, 0x7e, 0x7d, 0xf0, 0xd6, 0xf1, 0x5a, 0xfd, 0x63, 0x5d, 0xd5, 0xc9, 0xa9, 0xf6, 0x5f, 0x26,
-	0xec, 0xe6, 0x87, 0x1d, 0x81, 0x19, 0xcc, 0xe4, 0x11, 0xf5, 0xc1, 0xfd, 0x8d, 0xec, 0xd3, 0x99,
-	0x63, 0x06, 0x33, 0xf4, 0x04, 0xaa, 0x9c, 0x7a, 0x3e, 0xce, 0x4e, 0x39, 0xd8, 0xe0, 0x4d, 0x45,
-	0xd4, 0x51, 0x24, 0x74, 0x06, 0xad, 0xd9, 0x82, 0x91, 0x18, 0x33, 0xe6, 0x62, 0x4a, 0x13, 0x6a,
-	0x55, 0x7a, 0x46, 0xbf, 0x3e, 0xe8, 0x6e, 0xa4, 0x9d, 0x64, 0xb4, 0x33, 0xc1, 0x72, 0x9a, 0xb3,
-	0xe2, 0x27, 0xfa, 0x1a, 0x3a, 0x29, 0x4d, 0x9e, 0x91, 0x10, 0xbb, 0x79, 0x97, 0xdb, 0xb2, 0x50,
-	0x6f, 0xa3, 0xd0, 0xa5, 0x22, 0xe6, 0xcd, 0xb6, 0xd3, 0x32, 0x20, 0x8a, 0xe9, 0x37, 0xcd, 0x8b,
-	0x05, 0xbd, 0xca, 0x9d, 0xc5, 0xd6, 0xe6, 0xe1, 0xb4, 0x59, 0x19, 0xb0, 0xbf, 0x04, 0xf3, 0x74,
-	0x86, 0x3e, 0x83, 0x03, 0x16, 0x26, 0x3f, 0xbb, 0xcf, 0x17, 0x98, 0x2e, 0xdd, 0x88, 0x84, 0x21,
-	0xc3, 0x7e, 0x12, 0x07, 0x4c, 0xbe, 0x66, 0x35, 0x1b, 0xd8, 0xbe, 0xe0, 0x7c, 0x2b, 0x28, 0x17,
-	0x2b, 0x86, 0xfd, 0xbb, 0x01, 0x55, 0xf9, 0x66, 0x68, 0x08, 0x4d, 0xe6, 0x45, 0x69, 0x88, 0x5d,
-	0x3f, 0x89, 0x9f, 0x91, 0x79, 0x36, 0x8a, 0x77, 0xef, 0x7e, 0xe2, 0x89, 0xa4, 0x3a, 0x0d, 0x95,
-	0x32, 0x92, 0x19, 0xe8, 0x07, 0xb0, 0xfc, 0x05, 0xe3, 0x49, 0xe4, 0x92, 0x98, 0x71, 0xba, 0x88,
-	0x70, 0xcc, 0x75, 0x35, 0x53, 0xf6, 0xf8, 0xe8, 0xee, 0x6a, 0x23, 0x99, 0x35, 0xce, 0x93, 0x9c,
-	0x03, 0x7f, 0x0d, 0x51, 0x27, 0xd8, 0x7f, 0x1a, 0xd0, 0x2c, 0xcd, 0x0a, 0xf5, 0xa1, 0x73, 0xc5,
-	0x79, 0xaa, 0xe6, 0xeb, 0xfa, 0x49, 0x80, 0x45, 0xdb, 0x95, 0x7e, 0xd5, 0x69, 0x09, 0x5c, 0x92,
-	0x46, 0x02, 0x45, 0x8f, 0xa0, 0x4d, 0x53, 0xbf, 0x44, 0x14, 0x97, 0xaa, 0x39, 0x4d, 0x9a, 0xfa,
-	0x05, 0xde, 0x14, 0xac, 0x80, 0x50, 0xec, 0x73, 0xd7, 0xf7, 0x16, 0x0c, 0xbb, 0x3e, 0xf5, 0xd8,
-	0x95, 0xeb, 0x87, 0xde, 0x8b, 0x17, 0x99, 0x7e, 0xde, 0xd9, 0xe8, 0x62, 0x24, 0x38, 0x23, 0x41,
-	0x71, 0x1e, 0xa8, 0xe4, 0x91, 0xc8, 0x5d, 0xc1, 0xf6, 0x05, 0xc0, 0xea, 0x0b, 0x1d, 0x42, 0xdd,
-	0x0f, 0x3d, 0xc6, 0xa4, 0xaf, 0xd4, 0x85, 0x6b, 0x0e, 0x48, 0x48, 0x18, 0x8a, 0xa1, 0x2e, 0xec,
-	0xa4, 0x1e, 0xe7, 0x98, 0xc6, 0x52, 0xea, 0xda, 0x75, 0x1a, 0xb4, 0xcf, 0xa0, 0xbd, 0x26, 0x35,
-	0x34, 0x80, 0x9d, 0x4c, 0x6c, 0xb2, 0x5e, 0x7d, 0x60, 0xbd, 0x4e, 0x9d, 0x8e, 0x26, 0xda, 0xbf,
-	0x6d, 0xc3, 0x4e, 0x06, 0xbe, 0xb9, 0xdb, 0x8f, 0x00, 0xb4, 0x1f, 0x48, 0x20, 0xaf, 0x57, 0xc9,
-	0x68, 0xb5, 0x0c, 0x1f, 0x07, 0xe8, 0x21, 0xd4, 0x29, 0xf6, 0x13, 0x1a, 0xb8, 0x7c, 0x99, 0x62,
-	0xab, 0x52, 0x28, 0x06, 0x2a, 0x30, 0x5d, 0xa6, 0x18, 0x7d, 0x00, 0xcd, 0x8c, 0x96, 0x62, 0x4a,
-	0x92, 0xc0, 0xda, 0x2e, 0x94, 0x6b, 0xa8, 0xd0, 0xa5, 0x8c, 0xa0, 0x0f, 0xa1, 0xa5, 0xb4, 0xe4,
-	0x5e, 0x63, 0xca, 0x48, 0x12, 0x5b, 0xd5, 0x02, 0xb7, 0xa9, 0x62, 0xdf, 0xa9, 0x10, 0xfa, 0x08,
-	0xda, 0x59, 0xdd, 0x60, 0x41, 0x3d, 0x2e, 0xd8, 0xd0, 0x33, 0x72, 0x76, 0x4b, 0x05, 0x4f, 0xb3,
-	0x98, 0x50, 0x91, 0x90, 0xac, 0x17, 0xfb, 0xa2, 0x27, 0x37, 0x24, 0x8c, 0x5b, 0x75, 0x39, 0x94,
-	0x96, 0xc6, 0xc7, 0xc1, 0x39, 0x61, 0x1c, 0x3d, 0x86, 0x3d, 0xdd, 0xbc, 0x68, 0x4c, 0x51, 0x1b,
-	0x92, 0xaa, 0xbd, 0x2e, 0x1a, 0x93, 0xdc, 0x23, 0x00, 0xc6, 0x3d, 0xca, 0x5d, 0x4e, 0x22, 0x6c,
-	0x35, 0x0b, 0xe7, 0xd7, 0x24, 0x3e, 0x25, 0x11, 0x46, 0xdf, 0xc3, 0x01, 0xa7, 0x64, 0x3e, 0xc7,
-	0x42, 0x94, 0x71, 0x40, 0xc4, 0x7d, 0x54, 0xd5, 0x96, 0x9c, 0xe2, 0x7b, 0x77, 0x58, 0x46, 0xd2,
-	0x47, 0x9a, 0xed, 0xec, 0xf3, 0x35, 0x44, 0x9e, 0xfe, 0x15, 0xb4, 0x52, 0x6d, 0x3f, 0x55, 0xb0,
-	0x2d, 0x0b, 0xda, 0xaf, 0x93, 0x85, 0xb8, 0xb7, 0xf2, 0x99, 0xd3, 0x48, 0x33, 0xc7, 0x89, 0x4a,
-	0x76, 0x08, 0xf5, 0x82, 0xe9, 0xd1, 0x10, 0x76, 0x19, 0xa7, 0x1e, 0xc7, 0xf3, 0xa5, 0x14, 0x49,
-	0x6b, 0x70, 0xb8, 0xb9, 0xba, 0x24, 0x75, 0x92, 0xd1, 0xb2, 0xae, 0xf3, 0x34, 0xf4, 0x36, 0x54,
-	0xaf, 0xbd, 0x70, 0x21, 0xf6, 0xb8, 0xd1, 0x37, 0xb2, 0xb0, 0x82, 0xec, 0x7f, 0x4c, 0x78, 0x70,
-	0xe7, 0x56, 0x10, 0xef, 0xb9, 0x72, 0x4d, 0x49, 0x9f, 0xb5, 0xdc, 0x3a, 0xc2, 0x5a, 0x11, 0xe6,
-	0x57, 0x49, 0xa0, 0x58, 0xca, 0xe2, 0xa0, 0x20, 0x49, 0x78, 0x02, 0x6d, 0xc2, 0x5c, 0x3f, 0x09,
-	0x43, 0xe1, 0x71, 0x8f, 0xce, 0x99, 0x54, 0xe7, 0xae, 0x16, 0x12, 0x61, 0x23, 0x15, 0x1b, 0xd2,
-	0x39, 0x43, 0x9f, 0xc0, 0x5e, 0x81, 0x4d, 0x31, 0x5f, 0xd0, 0x58, 0x8a, 0x54, 0xf3, 0xdb, 0x39,
-	0xdf, 0x91, 0xc1, 0xb5, 0x8c, 0x08, 0x73, 0x4a, 0x7c, 0x29, 0xd5, 0xcd, 0x8c, 0x0b, 0x19, 0x44,
-	0xc7, 0x70, 0x3f, 0xa3, 0xe3, 0x40, 0x5c, 0x48, 0x2a, 0x8b, 0x59, 0xf7, 0xe4, 0xd5, 0xf7, 0xf2,
-	0xd0, 0x90, 0xce, 0xc5, 0x88, 0x98, 0x58, 0xf8, 0x2b, 0x7e, 0x32, 0xfb, 0x51, 0x9c, 0x13, 0xe0,
-	0x94, 0x5f, 0x59, 0x3b, 0xc5, 0x85, 0x9f, 0x73, 0x9e, 0x4a, 0xca, 0xa9, 0x60, 0xd8, 0x7f, 0x18,
-	0xd0, 0x59, 0x17, 0x10, 0x3a, 0x80, 0xca, 0x4f, 0x78, 0x59, 0x7a, 0x51, 0x01, 0x88, 0x31, 0x85,
-	0xde, 0x0c, 0x87, 0xa5, 0x1d, 0xa4, 0x20, 0xb1, 0xa1, 0xfc, 0x24, 0x4a, 0x3d, 0x5a, 0x36, 0xb7,
-	0x06, 0xd1, 0x3e, 0x98, 0x49, 0x2a, 0x5f, 0x4a, 0x87, 0xcc, 0x24, 0x5d, 0x0d, 0x5e, 0x3c, 0x48,
-	0x79, 0xf0, 0xc8, 0x82, 0xed, 0x45, 0x4c, 0xb8, 0x75, 0xaf, 0x90, 0x23, 0x11, 0xfb, 0x57, 0x03,
-	0xf6, 0x36, 0x44, 0x2a, 0x16, 0x56, 0xd1, 0x8a, 0xe5, 0x85, 0x55, 0xf0, 0x22, 0xfa, 0x18, 0x3a,
-	0x7a, 0x0b, 0xb8, 0xfa, 0xaf, 0xd1, 0x2c, 0xb8, 0xb1, 0xad, 0xa3, 0x13, 0x15, 0x14, 0xcb, 0x8b,
-	0x30, 0x97, 0xc5, 0x5e, 0xca, 0xae, 0x12, 0x2e, 0xb7, 0xbe, 0x1e, 0x1e, 0x10, 0x36, 0xc9, 0xf0,
-	0xc7, 0x9f, 0x43, 0xab, 0xac, 0x73, 0xb4, 0x03, 0x95, 0xe1, 0xf9, 0x79, 0x67, 0x0b, 0x75, 0xa0,
-	0x31, 0x19, 0x5e, 0x5c, 0x9e, 0x9f, 0xb9, 0xce, 0x70, 0x3a, 0x7e, 0xda, 0x31, 0x50, 0x0b, 0xc0,
-	0x19, 0x4e, 0xcf, 0xdc, 0xf3, 0xf1, 0xc5, 0x78, 0xda, 0x31, 0x4f, 0xbe, 0x78, 0x79, 0xd3, 0x35,
-	0x5e, 0xdd, 0x74, 0x8d, 0xff, 0x6e, 0xba, 0xc6, 0x2f, 0xb7, 0xdd, 0xad, 0x57, 0xb7, 0xdd, 0xad,
-	0xbf, 0x6f, 0xbb, 0x5b, 0xf0, 0xd0, 0x4f, 0xa2, 0xe3, 0xd9, 0x92, 0xe3, 0x40, 0xec, 0x1d, 0xf9,
-	0xcb, 0x4b, 0xa3, 0x75, 0x8b, 0xfd, 0x1f, 0x00, 0x00, 0xff, 0xff, 0xfe, 0xc0, 0x21, 0x7f, 0xd2,
-	0x09, 0x00, 0x00,
+func init() { proto.RegisterFile("settings.proto", fileDescriptor_settings_a4c741de559a9bcb) }
+
+var fileDescriptor_settings_a4c741de559a9bcb = []byte{
+	// 1252 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x95, 0xcf, 0x8f, 0xdb, 0x44,
+	0x14, 0xc7, 0xd7, 0x4e, 0xd2, 0x6c, 0x5e, 0x7e, 0xee, 0xb4, 0x5d, 0x99, 0x82, 0xd2, 0xd4, 0x55,
+	0xcb, 0x52, 0xda, 0x05, 0xad, 0x04, 0x42, 0xe5, 0x00, 0xd9, 0xec, 0x52, 0x56, 0xec, 0xd2, 0xe2,
+	0x44, 0x70, 0x34, 0x8e, 0x3d, 0xf5, 0x1a, 0x6c, 0x8f, 0x3b, 0x33, 0x2e, 0x4a, 0xff, 0x0a, 0x0e,
+	0xfc, 0x07, 0x70, 0xe4, 0xce, 0x8d, 0x2b, 0x3d, 0xf6, 0x82, 0x84, 0x84, 0x84, 0x50, 0xfb, 0x8f,
+	0xa0, 0x99, 0xb1, 0x1d, 0x3b, 0xc9, 0x0a, 0x24, 0x6e, 0xc9, 0xf7, 0x7d, 0xde, 0x9b, 0x79, 0xf3,
+	0x7e, 0x18, 0x7a, 0x0c, 0x73, 0x1e, 0xc4, 0x3e, 0xdb, 0x4f, 0x28, 0xe1, 0x04, 0xf5, 0xf3, 0xff,
+	0x76, 0x44, 0x3c, 0x1c, 0x32, 0xf3, 0x09, 0xf4, 0xa7, 0x98, 0x3e, 0x0d, 0x5c, 0x3c, 0xcd, 0x2c,
+	0xe8, 0x4d, 0xe8, 0x30, 0x25, 0xd9, 0xb1, 0x13, 0x61, 0x43, 0x1b, 0xe9, 0x7b, 0xad, 0xc3, 0xfa,
+	0xf3, 0xbf, 0xae, 0x6f, 0x59, 0xed, 0xcc, 0xf2, 0xb9, 0x13, 0x61, 0xf4, 0x1e, 0x6c, 0xe7, 0xe1,
+	0x0c, 0x7d, 0xa4, 0xef, 0xb5, 0x0f, 0x5e, 0xdb, 0x5f, 0x89, 0xbf, 0x9f, 0x47, 0xb5, 0x0a, 0xd4,
+	0xfc, 0x55, 0x87, 0xed, 0xe2, 0xb0, 0x9b, 0xa0, 0x7b, 0x73, 0x79, 0x44, 0xfb, 0xe0, 0xf2, 0x9a,
+	0xf7, 0xd1, 0xdc, 0xd2, 0xbd, 0x39, 0xba, 0x0b, 0x0d, 0x4e, 0x1d, 0x17, 0x67, 0xa7, 0xec, 0xae,
+	0x71, 0x33, 0x61, 0xb5, 0x14, 0x84, 0x8e, 0xa1, 0x37, 0x4f, 0x59, 0x10, 0x63, 0xc6, 0x6c, 0x4c,
+	0x29, 0xa1, 0x46, 0x6d, 0xa4, 0xed, 0xb5, 0x0f, 0x86, 0x6b, 0x6e, 0x87, 0x19, 0x76, 0x2c, 0x28,
+	0xab, 0x3b, 0x2f, 0xff, 0x45, 0x9f, 0xc1, 0x20, 0xa1, 0xe4, 0x71, 0x10, 0x62, 0xbb, 0xc8, 0xb2,
+	0x2e, 0x03, 0x8d, 0xd6, 0x02, 0x3d, 0x52, 0x60, 0x91, 0x6c, 0x3f, 0xa9, 0x0a, 0x22, 0x58, 0xfe,
+	0xa6, 0x45, 0x30, 0x6f, 0x54, 0xdb, 0x18, 0x6c, 0xa5, 0x1e, 0x56, 0x9f, 0x55, 0x05, 0xf3, 0x63,
+	0xd0, 0x8f, 0xe6, 0xe8, 0x3e, 0xec, 0xb2, 0x90, 0x7c, 0x67, 0x3f, 0x49, 0x31, 0x5d, 0xd8, 0x51,
+	0x10, 0x86, 0x0c, 0xbb, 0x24, 0xf6, 0x98, 0x7c, 0xcd, 0x46, 0x56, 0xb0, 0x2b, 0x82, 0xf9, 0x42,
+	0x20, 0x67, 0x4b, 0xc2, 0xfc, 0x59, 0x83, 0x86, 0x7c, 0x33, 0x34, 0x86, 0x2e, 0x73, 0xa2, 0x24,
+	0xc4, 0xb6, 0x4b, 0xe2, 0xc7, 0x81, 0x9f, 0x95, 0xe2, 0x8d, 0xcd, 0x4f, 0x3c, 0x95, 0xa8, 0xd5,
+	0x51, 0x2e, 0x13, 0xe9, 0x81, 0xbe, 0x06, 0xc3, 0x4d, 0x19, 0x27, 0x91, 0x1d, 0xc4, 0x8c, 0xd3,
+	0x34, 0xc2, 0x31, 0xcf, 0xa3, 0xe9, 0x32, 0xc7, 0xdb, 0x9b, 0xa3, 0x4d, 0xa4, 0xd7, 0x49, 0xe1,
+	0x64, 0xed, 0xba, 0x2b, 0x8a, 0x3a, 0xc1, 0xfc, 0x45, 0x83, 0x6e, 0xa5, 0x56, 0x68, 0x0f, 0x06,
+	0xe7, 0x9c, 0x27, 0xaa, 0xbe, 0xb6, 0x4b, 0x3c, 0x2c, 0xd2, 0xae, 0xed, 0x35, 0xac, 0x9e, 0xd0,
+	0x25, 0x34, 0x11, 0x2a, 0xba, 0x0d, 0x7d, 0x9a, 0xb8, 0x15, 0x50, 0x5c, 0xaa, 0x65, 0x75, 0x69,
+	0xe2, 0x96, 0xb8, 0x19, 0x18, 0x5e, 0x40, 0xb1, 0xcb, 0x6d, 0xd7, 0x49, 0x19, 0xb6, 0x5d, 0xea,
+	0xb0, 0x73, 0xdb, 0x0d, 0x9d, 0x67, 0xcf, 0xb2, 0xfe, 0x79, 0x7d, 0x2d, 0x8b, 0x89, 0x60, 0x26,
+	0x02, 0xb1, 0xae, 0x2a, 0xe7, 0x89, 0xf0, 0x5d, 0xca, 0xe6, 0x19, 0xc0, 0xf2, 0x1f, 0xba, 0x0e,
+	0x6d, 0x37, 0x74, 0x18, 0x93, 0x73, 0xa5, 0x2e, 0xdc, 0xb2, 0x40, 0x4a, 0x62, 0xa0, 0x18, 0x1a,
+	0x42, 0x33, 0x71, 0x38, 0xc7, 0x34, 0x36, 0xf4, 0xd2, 0xd4, 0xe5, 0xa2, 0x79, 0x0c, 0xfd, 0x95,
+	0x56, 0x43, 0x07, 0xd0, 0xcc, 0x9a, 0x4d, 0xc6, 0x6b, 0x1f, 0x18, 0x17, 0x75, 0xa7, 0x95, 0x83,
+	0xe6, 0x8f, 0x75, 0x68, 0x66, 0xe2, 0x7f, 0x9f, 0xf6, 0x9b, 0x00, 0xf9, 0x3c, 0x04, 0x9e, 0xbc,
+	0x5e, 0x2d, 0xc3, 0x5a, 0x99, 0x7e, 0xe2, 0xa1, 0x5b, 0xd0, 0xa6, 0xd8, 0x25, 0xd4, 0xb3, 0xf9,
+	0x22, 0xc1, 0x46, 0xad, 0x14, 0x0c, 0x94, 0x61, 0xb6, 0x48, 0x30, 0x7a, 0x0b, 0xba, 0x19, 0x96,
+	0x60, 0x1a, 0x10, 0xcf, 0xa8, 0x97, 0xc2, 0x75, 0x94, 0xe9, 0x91, 0xb4, 0xa0, 0xb7, 0xa1, 0xa7,
+	0x7a, 0xc9, 0x7e, 0x8a, 0x29, 0x0b, 0x48, 0x6c, 0x34, 0x4a, 0x6c, 0x57, 0xd9, 0xbe, 0x54, 0x26,
+	0x74, 0x0f, 0xfa, 0x59, 0x5c, 0x2f, 0xa5, 0x0e, 0x17, 0x34, 0x8c, 0xb4, 0x82, 0xee, 0x29, 0xe3,
+	0x51, 0x66, 0x13, 0x5d, 0x24, 0x5a, 0xd6, 0x89, 0x5d, 0x91, 0x93, 0x1d, 0x06, 0x8c, 0x1b, 0x6d,
+	0x59, 0x94, 0x5e, 0xae, 0x9f, 0x78, 0xa7, 0x01, 0xe3, 0xe8, 0x0e, 0xec, 0xe4, 0xc9, 0x8b, 0xc4,
+	0x14, 0xda, 0x91, 0x68, 0x3e, 0xeb, 0x22, 0x31, 0xc9, 0xde, 0x04, 0x60, 0xdc, 0xa1, 0xdc, 0xe6,
+	0x41, 0x84, 0x8d, 0x6e, 0xe9, 0xfc, 0x96, 0xd4, 0x67, 0x41, 0x84, 0xd1, 0x57, 0xb0, 0xcb, 0x69,
+	0xe0, 0xfb, 0x58, 0x34, 0x65, 0xec, 0x05, 0xe2, 0x3e, 0x2a, 0x6a, 0x4f, 0x56, 0xf1, 0xc6, 0x86,
+	0x91, 0x91, 0xf8, 0x24, 0xa7, 0xad, 0x2b, 0x7c, 0x45, 0x91, 0xa7, 0x7f, 0x0a, 0xbd, 0x24, 0x1f,
+	0x3f, 0x15, 0xb0, 0x2f, 0x03, 0x9a, 0x17, 0xb5, 0x85, 0xb8, 0xb7, 0x9a, 0x33, 0xab, 0x93, 0x64,
+	0x13, 0x27, 0x22, 0x99, 0x3f, 0x69, 0xd0, 0x2e, 0x4d, 0x3d, 0x1a, 0xc3, 0x36, 0xe3, 0xd4, 0xe1,
+	0xd8, 0x5f, 0xc8, 0x2e, 0xe9, 0x1d, 0x5c, 0x5f, 0xdf, 0x5d, 0x12, 0x9d, 0x66, 0x58, 0x96, 0x76,
+	0xe1, 0x86, 0xae, 0x41, 0xe3, 0xa9, 0x13, 0xa6, 0x62, 0x91, 0x6b, 0x7b, 0x5a, 0x66, 0x56, 0x12,
+	0x7a, 0x1f, 0x1a, 0x34, 0x0d, 0x31, 0x33, 0x6a, 0x17, 0xec, 0xc5, 0xf2, 0x06, 0x4a, 0x43, 0x6c,
+	0x29, 0xdc, 0xfc, 0x53, 0x87, 0xab, 0x1b, 0xd7, 0x89, 0x28, 0xc4, 0x72, 0xdc, 0x2a, 0x8d, 0xdd,
+	0x2a, 0x66, 0x4e, 0xcc, 0x64, 0x84, 0xf9, 0x39, 0xf1, 0x14, 0xa5, 0x76, 0x03, 0x28, 0x49, 0x02,
+	0x77, 0xa1, 0x1f, 0x30, 0xdb, 0x25, 0x61, 0x28, 0x96, 0x83, 0x43, 0x7d, 0x26, 0xdb, 0x7a, 0x3b,
+	0xef, 0xc0, 0x80, 0x4d, 0x94, 0x6d, 0x4c, 0x7d, 0x86, 0xde, 0x85, 0x9d, 0x12, 0x4d, 0x31, 0x4f,
+	0x69, 0x6c, 0xd4, 0x4b, 0x7c, 0xbf, 0xe0, 0x2d, 0x69, 0x5c, 0xf1, 0x88, 0x30, 0xa7, 0x81, 0x6b,
+	0x34, 0x36, 0x7a, 0x9c, 0x49, 0x23, 0xda, 0x87, 0xcb, 0x19, 0x8e, 0x3d, 0x71, 0x21, 0xd9, 0x92,
+	0xcc, 0xb8, 0x24, 0xaf, 0xbe, 0x53, 0x98, 0xc6, 0xd4, 0x17, 0xb5, 0x65, 0xe2, 0x4b, 0xb1, 0xe4,
+	0xc9, 0xfc, 0x1b, 0x71, 0x8e, 0x87, 0x13, 0x7e, 0x6e, 0x34, 0xcb, 0x5f, 0x8a, 0x82, 0x79, 0x28,
+	0x91, 0x23, 0x41, 0x98, 0xbf, 0x6b, 0x30, 0x58, 0xed, 0x3c, 0xb4, 0x0b, 0xb5, 0x6f, 0xf1, 0xa2,
+	0xf2, 0xa2, 0x42, 0x10, 0xe5, 0x0d, 0x9d, 0x39, 0x0e, 0x2b, 0xcb, 0x4b, 0x49, 0x62, 0xb5, 0xb9,
+	0x24, 0x4a, 0x1c, 0x5a, 0xdd, 0x0a, 0xb9, 0x88, 0xae, 0x80, 0x4e, 0x12, 0xa3, 0x5e, 0x32, 0xe9,
+	0x24, 0x59, 0x36, 0x8c, 0x78, 0x90, 0x95, 0x86, 0x31, 0xa0, 0x9e, 0xc6, 0x01, 0x37, 0x2e, 0x95,
+	0x7c, 0xa4, 0x82, 0x6e, 0x40, 0xcb, 0x89, 0x3d, 0xdb, 0xa7, 0x24, 0x4d, 0x8c, 0xe6, 0x48, 0x2b,
+	0x72, 0xdc, 0x76, 0x62, 0xef, 0x81, 0x50, 0xcd, 0x1f, 0x34, 0xd8, 0x59, 0x1b, 0x00, 0xb1, 0x0c,
+	0xcb, 0x63, 0x5e, 0x5d, 0x86, 0xa5, 0x39, 0x47, 0xef, 0xc0, 0x20, 0xdf, 0x30, 0x76, 0xfe, 0xd9,
+	0xd5, 0x4b, 0x93, 0xde, 0xcf, 0xad, 0x53, 0x65, 0x14, 0x8b, 0x31, 0x60, 0x36, 0x8b, 0x9d, 0x84,
+	0x9d, 0x13, 0x2e, 0xbf, 0x28, 0x79, 0x7d, 0x21, 0x60, 0xd3, 0x4c, 0x37, 0x7f, 0xd3, 0xa1, 0xbf,
+	0xd2, 0xe7, 0x68, 0x04, 0xdb, 0x14, 0x33, 0x92, 0x52, 0x57, 0x5c, 0x48, 0x2b, 0x2e, 0x54, 0xa8,
+	0xc8, 0x84, 0x16, 0x49, 0x70, 0xb6, 0xf0, 0xf4, 0x12, 0xb2, 0x94, 0xd1, 0x03, 0x68, 0xce, 0x1d,
+	0xdf, 0x77, 0x7c, 0x9c, 0x0d, 0xd8, 0xbd, 0x7f, 0x1b, 0xb0, 0xfd, 0x43, 0xc5, 0x1f, 0xc7, 0x9c,
+	0x2e, 0xac, 0xdc, 0xbb, 0xb2, 0x06, 0xea, 0xff, 0x73, 0x0d, 0x34, 0xd6, 0xd6, 0xc0, 0xb5, 0x4f,
+	0xa0, 0x53, 0x3e, 0x77, 0xd9, 0x6b, 0xda, 0x5a, 0xaf, 0x2d, 0x57, 0x49, 0xab, 0x12, 0xe3, 0xbe,
+	0xfe, 0x81, 0x76, 0xe7, 0x43, 0xe8, 0x55, 0x6f, 0x81, 0x9a, 0x50, 0x1b, 0x9f, 0x9e, 0x0e, 0xb6,
+	0xd0, 0x00, 0x3a, 0xd3, 0xf1, 0xd9, 0xa3, 0xd3, 0x63, 0xdb, 0x1a, 0xcf, 0x4e, 0x1e, 0x0e, 0x34,
+	0xd4, 0x03, 0xb0, 0xc6, 0xb3, 0x63, 0xfb, 0xf4, 0xe4, 0xec, 0x64, 0x36, 0xd0, 0x0f, 0x3f, 0x7a,
+	0xfe, 0x72, 0xa8, 0xbd, 0x78, 0x39, 0xd4, 0xfe, 0x7e, 0x39, 0xd4, 0xbe, 0x7f, 0x35, 0xdc, 0x7a,
+	0xf1, 0x6a, 0xb8, 0xf5, 0xc7, 0xab, 0xe1, 0x16, 0xdc, 0x72, 0x49, 0xb4, 0x3f, 0x5f, 0x70, 0xec,
+	0x89, 0xaf, 0x83, 0xfc, 0xe5, 0x24, 0xd1, 0xea, 0x03, 0xfc, 0x33, 0x00, 0xcd, 0xe2, 0xf5, 0x03,
+	0x78, 0x0b, 0x00, 0x00,
 }